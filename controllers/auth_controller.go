@@ -4,33 +4,48 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"time"
 	"warehouse-backend/database"
+	"warehouse-backend/middleware"
 	"warehouse-backend/models"
 	"warehouse-backend/utils"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// lockRegistration membuat baris RegistrationLock jika belum ada lalu menguncinya sampai transaksi selesai
+func lockRegistration(tx *gorm.DB) error {
+	lock := models.RegistrationLock{ID: 1}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&lock).Error; err != nil {
+		return err
+	}
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lock, lock.ID).Error
+}
+
 // RegisterUser godoc
 // @Summary Registrasi user baru
-// @Description Membuat akun user baru
+// @Description Membuat akun user baru. User pertama selalu menjadi admin. Selanjutnya tergantung REGISTRATION_MODE:
+// @Description invite (default) mewajibkan invite_code yang valid, open membuat user viewer, disabled menolak registrasi termasuk yang memakai invite.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param user body models.UserSwagger true "User Data"
 // @Success 201 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /auth/register [post]
 func RegisterUser(c *gin.Context) {
 	var user models.User
 
 	var payload struct {
 		gorm.Model
-		Name     string `json:"name"`
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Name       string `json:"name"`
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		InviteCode string `json:"invite_code"`
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -48,12 +63,76 @@ func RegisterUser(c *gin.Context) {
 	user.Email = payload.Email
 	user.Password = string(hashedPassword)
 
-	if err := database.DB.Create(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Registrasi diserialisasi lewat baris RegistrationLock agar dua registrasi pertama yang
+		// serentak tidak sama-sama melihat nol user dan menjadi admin
+		if err := lockRegistration(tx); err != nil {
+			return err
+		}
+
+		var users int64
+		if err := tx.Model(&models.User{}).Count(&users).Error; err != nil {
+			return err
+		}
+
+		var invite *models.Invite
+		switch {
+		case users == 0:
+			// User pertama menjadi admin agar sistem bisa dikelola
+			user.Role = models.RoleAdmin
+		case registrationMode() == models.RegistrationDisabled:
+			return errRegistrationDisabled
+		case payload.InviteCode != "":
+			invite, err = findUsableInvite(tx, payload.InviteCode, payload.Email)
+			if err != nil {
+				return err
+			}
+			user.Role = invite.Role
+		case registrationMode() == models.RegistrationOpen:
+			user.Role = models.RoleViewer
+		default:
+			return errInviteRequired
+		}
+
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		if invite != nil {
+			now := time.Now()
+			return tx.Model(invite).Updates(models.Invite{UsedAt: &now, UsedBy: &user.ID}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRegistrationDisabled):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Registration is disabled"})
+		case errors.Is(err, errInviteRequired):
+			c.JSON(http.StatusForbidden, gin.H{"error": "A valid invite code is required"})
+		case errors.Is(err, errInvalidInvite):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Invite code is invalid, expired or for another email"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully"})
+	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully", "role": user.Role})
+}
+
+// GetRegistrationMode godoc
+// @Summary Mode registrasi
+// @Description Mengembalikan mode registrasi server (open, invite, disabled) agar frontend bisa menampilkan form yang sesuai
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /auth/registration [get]
+func GetRegistrationMode(c *gin.Context) {
+	var users int64
+	database.DB.Model(&models.User{}).Count(&users)
+
+	c.JSON(http.StatusOK, gin.H{"mode": registrationMode(), "bootstrap": users == 0})
 }
 
 // LoginUser godoc
@@ -63,9 +142,10 @@ func RegisterUser(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param credentials body models.LoginCredentials true "User Credentials"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} models.TokenResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /auth/login [post]
 func LoginUser(c *gin.Context) {
 	var credentials models.LoginCredentials
@@ -102,11 +182,152 @@ func LoginUser(c *gin.Context) {
 		return
 	}
 
-	token, err := utils.GenerateToken(user.ID)
+	if !user.IsActive() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account disabled"})
+		return
+	}
+
+	response, _, err := issueTokenPair(database.DB, user, "")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	c.JSON(http.StatusOK, response)
+}
+
+// RefreshToken godoc
+// @Summary Perbarui access token
+// @Description Menukar refresh token dengan access token dan refresh token baru. Refresh token lama langsung tidak berlaku;
+// @Description memakai ulang refresh token lama akan mencabut seluruh sesi tersebut.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body models.RefreshRequest true "Refresh token"
+// @Success 200 {object} models.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /auth/refresh [post]
+func RefreshToken(c *gin.Context) {
+	var request models.RefreshRequest
+	if err := c.ShouldBindJSON(&request); err != nil || request.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refresh_token is required"})
+		return
+	}
+
+	var response models.TokenResponse
+	var reused bool
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		response, err = rotateRefreshToken(tx, request.RefreshToken)
+		// Pencabutan family harus tetap di-commit walaupun request ditolak
+		if errors.Is(err, errRefreshTokenReused) {
+			reused = true
+			return nil
+		}
+		return err
+	})
+	if reused {
+		err = errRefreshTokenReused
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, errRefreshTokenReused):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token reuse detected, session revoked"})
+		case errors.Is(err, errInvalidRefreshToken):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// LogoutUser godoc
+// @Summary Logout
+// @Description Mencabut access token yang sedang dipakai beserta sesi refresh token yang dikirim. Dengan all=true semua sesi user dicabut.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body models.LogoutRequest false "Refresh token sesi ini"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /auth/logout [post]
+func LogoutUser(c *gin.Context) {
+	var request models.LogoutRequest
+	// Body opsional
+	_ = c.ShouldBindJSON(&request)
+
+	userID := middleware.CurrentUserID(c)
+	now := time.Now()
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		revoked := models.RevokedToken{
+			JTI:       middleware.CurrentTokenID(c),
+			ExpiresAt: middleware.CurrentTokenExpiry(c),
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&revoked).Error; err != nil {
+			return err
+		}
+
+		if request.All {
+			if err := revokeUserSessions(tx, userID, now); err != nil {
+				return err
+			}
+		} else if request.RefreshToken != "" {
+			var refresh models.RefreshToken
+			err := tx.Where("token_hash = ? AND user_id = ?", utils.HashToken(request.RefreshToken), userID).
+				First(&refresh).Error
+			if err == nil {
+				if err := revokeRefreshFamily(tx, refresh.FamilyID, now); err != nil {
+					return err
+				}
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+
+		// Bersihkan catatan token yang sudah kedaluwarsa
+		return tx.Where("expires_at < ?", now).Delete(&models.RevokedToken{}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// GetMe godoc
+// @Summary Profil user yang sedang login
+// @Description Mengembalikan data user pemilik token beserta daftar permission dari role-nya
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.Profile
+// @Failure 401 {object} map[string]string
+// @Router /auth/me [get]
+func GetMe(c *gin.Context) {
+	user := middleware.CurrentUser(c)
+
+	c.JSON(http.StatusOK, models.Profile{
+		User:        *user,
+		Permissions: models.PermissionsFor(user.Role),
+	})
+}
+
+// GetJWKS menerbitkan public key JWT dalam format JWK Set di /.well-known/jwks.json
+// agar layanan lain dapat memverifikasi token. Dengan HS256 daftar kunci kosong.
+func GetJWKS(c *gin.Context) {
+	keys, err := utils.JWKS()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load signing keys"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
 }
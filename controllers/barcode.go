@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/filestore"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Batas parameter gambar barcode agar satu request tidak membuat gambar raksasa
+const (
+	maxBarcodeSize = 4000
+	minBarcodeDPI  = 72
+	maxBarcodeDPI  = 1200
+)
+
+// barcodeCacheControl: barcode hanya bergantung pada SKU sehingga aman di-cache oleh browser.
+// private karena endpoint memerlukan login.
+const barcodeCacheControl = "private, max-age=86400"
+
+// barcodeRequest adalah parameter query GET /products/barcode/:sku
+type barcodeRequest struct {
+	Format    string
+	Symbology string
+	Width     int
+	Height    int
+	DPI       int
+}
+
+// parseBarcodeRequest membaca dan memvalidasi format, symbology, width, height dan dpi
+func parseBarcodeRequest(c *gin.Context) (barcodeRequest, error) {
+	req := barcodeRequest{
+		Format:    strings.ToLower(c.DefaultQuery("format", "png")),
+		Symbology: strings.ToLower(c.DefaultQuery("symbology", utils.DefaultSymbology())),
+	}
+	if req.Format != "png" && req.Format != "svg" {
+		return req, errors.New("format must be png or svg")
+	}
+	if !utils.IsValidSymbology(req.Symbology) {
+		return req, fmt.Errorf("unsupported symbology %q", req.Symbology)
+	}
+
+	params := []struct {
+		name     string
+		dst      *int
+		min, max int
+	}{
+		{"width", &req.Width, 1, maxBarcodeSize},
+		{"height", &req.Height, 1, maxBarcodeSize},
+		{"dpi", &req.DPI, minBarcodeDPI, maxBarcodeDPI},
+	}
+	for _, p := range params {
+		value := c.Query(p.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < p.min || n > p.max {
+			return req, fmt.Errorf("%s must be a whole number between %d and %d", p.name, p.min, p.max)
+		}
+		*p.dst = n
+	}
+	return req, nil
+}
+
+// isStored reports whether the request asks for exactly the PNG saved at product creation
+func (r barcodeRequest) isStored() bool {
+	return r.Format == "png" && r.Symbology == utils.DefaultSymbology() && r.Width == 0 && r.Height == 0 && r.DPI == 0
+}
+
+// options mengubah parameter request menjadi opsi render. dpi menentukan lebar modul
+// (X-dimension 0,33 mm) kecuali width diisi, karena width selalu diutamakan.
+func (r barcodeRequest) options() utils.BarcodeOptions {
+	opts := utils.DefaultBarcodeOptions(r.Symbology)
+	if r.DPI > 0 {
+		module := utils.ModuleWidthForDPI(r.DPI)
+		// Tinggi bar mengikuti perbandingan ukuran default (50 modul)
+		opts.BarHeight = opts.BarHeight / opts.ModuleWidth * module
+		opts.ModuleWidth = module
+	}
+	opts.Width = r.Width
+	opts.Height = r.Height
+	return opts
+}
+
+// renderBarcode merender barcode sesuai request dan mengembalikan isi serta content type-nya
+func renderBarcode(sku string, r barcodeRequest) ([]byte, string, error) {
+	opts := r.options()
+	if r.Format == "svg" {
+		svg, err := utils.RenderBarcodeSVG(sku, opts, r.DPI)
+		return svg, "image/svg+xml", err
+	}
+
+	img, err := utils.RenderBarcode(sku, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// etag membuat ETag dari SKU dan semua parameter yang memengaruhi hasil render
+func (r barcodeRequest) etag(sku string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d|%d|%d", sku, r.Format, r.Symbology, r.Width, r.Height, r.DPI)))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// storedBarcodeETag membuat ETag dari file barcode yang tersimpan sehingga berubah jika file dibuat ulang
+func storedBarcodeETag(info filestore.ObjectInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime.UnixNano(), info.Size)
+}
+
+// barcodeTransaction menjalankan fn di dalam transaksi database. Gambar barcode yang dicatat fn
+// ke batch sudah dirender di dalam transaksi (kode yang tidak bisa di-encode membatalkannya),
+// tetapi baru ditulis atau dihapus setelah commit berhasil, sehingga insert yang gagal tidak
+// meninggalkan file yatim. Kegagalan storage setelah commit hanya dicatat di log karena gambar
+// yang hilang dibuat ulang saat diminta atau oleh "barcodes reconcile".
+func barcodeTransaction(ctx context.Context, fn func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error) error {
+	var barcodes filestore.BarcodeBatch
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(tx, &barcodes)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := barcodes.Apply(ctx); err != nil {
+		log.Printf("Warning: failed to update barcode images: %v", err)
+	}
+	return nil
+}
+
+// openStoredBarcode membuka PNG barcode sebuah kode (SKU atau kode lokasi) dari storage aktif.
+// File yang hilang (misalnya setelah storage dipindah) dibuat ulang dari kodenya.
+func openStoredBarcode(c *gin.Context, code string) (io.ReadCloser, filestore.ObjectInfo, error) {
+	ctx := c.Request.Context()
+	reader, info, err := filestore.Barcodes.Open(ctx, filestore.BarcodeKey(code))
+	if errors.Is(err, filestore.ErrNotFound) {
+		if _, err := filestore.GenerateBarcode(ctx, code); err != nil {
+			return nil, info, err
+		}
+		reader, info, err = filestore.Barcodes.Open(ctx, filestore.BarcodeKey(code))
+	}
+	return reader, info, err
+}
+
+// sendStoredBarcode mengirim PNG yang sudah dibuka beserta ETag-nya, atau 304 jika klien sudah memilikinya
+func sendStoredBarcode(c *gin.Context, reader io.ReadCloser, info filestore.ObjectInfo) {
+	defer reader.Close()
+	if notModified(c, storedBarcodeETag(info)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.DataFromReader(http.StatusOK, info.Size, "image/png", reader, nil)
+}
+
+// notModified menulis header cache dan mengembalikan true jika klien sudah memiliki versi terbaru
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", barcodeCacheControl)
+
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"warehouse-backend/filestore"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxBulkProducts membatasi jumlah produk dalam satu request bulk
+const maxBulkProducts = 1000
+
+// errBulkRollback membatalkan transaksi bulk atomik jika ada baris yang gagal
+var errBulkRollback = errors.New("bulk insert rolled back")
+
+// bulkRowError mengubah error satu baris menjadi pesan untuk klien
+func bulkRowError(err error) string {
+	var invalid errInvalidProduct
+	if errors.As(err, &invalid) {
+		return invalid.Error()
+	}
+	if errors.Is(err, errSKUExists) {
+		return "SKU already exists"
+	}
+	return "Failed to save product"
+}
+
+// BulkInsertProducts godoc
+// @Summary Menambahkan banyak produk sekaligus
+// @Description Menyimpan data produk dalam jumlah banyak menggunakan JSON array. Setiap produk divalidasi dan dibuatkan
+// @Description SKU, status dan barcode seperti POST /products. Dengan atomic=true (default) semua produk dibatalkan jika satu
+// @Description saja gagal; dengan atomic=false produk yang valid tetap disimpan. Response berisi hasil per indeks array.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param products body []models.ProductSwagger true "Daftar Produk"
+// @Param atomic query bool false "Semua atau tidak sama sekali (default true)"
+// @Success 201 {object} models.BulkInsertResponse "Semua produk tersimpan"
+// @Success 207 {object} models.BulkInsertResponse "Sebagian produk tersimpan (atomic=false)"
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} models.BulkInsertResponse "Tidak ada produk yang tersimpan"
+// @Router /products/bulk [post]
+func BulkInsertProducts(c *gin.Context) {
+	atomic := true
+	if value := c.Query("atomic"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid atomic value"})
+			return
+		}
+		atomic = parsed
+	}
+
+	// Parse JSON request body per elemen agar satu baris yang rusak tidak menggagalkan parsing
+	var rows []json.RawMessage
+	if err := c.ShouldBindJSON(&rows); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Validasi: Pastikan data tidak kosong
+	if len(rows) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Data produk tidak boleh kosong"})
+		return
+	}
+	if len(rows) > maxBulkProducts {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Maksimal " + strconv.Itoa(maxBulkProducts) + " produk per request"})
+		return
+	}
+
+	response := models.BulkInsertResponse{Atomic: atomic, Results: make([]models.BulkRowResult, len(rows))}
+	products := make([]models.Product, len(rows))
+	valid := make([]bool, len(rows))
+	invalid := 0
+
+	for i, raw := range rows {
+		response.Results[i] = models.BulkRowResult{Index: i}
+
+		var err error
+		if err = json.Unmarshal(raw, &products[i]); err != nil {
+			err = errInvalidProduct{"Invalid product: " + err.Error()}
+		} else {
+			err = validateNewProduct(products[i])
+		}
+		if err != nil {
+			response.Results[i].Status = models.BulkFailed
+			response.Results[i].Error = bulkRowError(err)
+			invalid++
+			continue
+		}
+		valid[i] = true
+	}
+
+	// Mode atomik: tidak ada yang disimpan jika ada baris yang tidak valid
+	if atomic && invalid > 0 {
+		for i := range response.Results {
+			if valid[i] {
+				response.Results[i].Status = models.BulkSkipped
+			}
+		}
+		response.Failed = invalid
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	userID := middleware.CurrentUserID(c)
+	if atomic {
+		bulkInsertAtomic(c.Request.Context(), products, userID, &response)
+	} else {
+		bulkInsertEach(c.Request.Context(), products, valid, userID, &response)
+	}
+
+	for _, result := range response.Results {
+		if result.Status == models.BulkCreated {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+
+	switch {
+	case response.Failed == 0:
+		c.JSON(http.StatusCreated, response)
+	case response.Created == 0:
+		c.JSON(http.StatusUnprocessableEntity, response)
+	default:
+		c.JSON(http.StatusMultiStatus, response)
+	}
+}
+
+// bulkInsertAtomic menyimpan semua produk dalam satu transaksi. Jika satu baris gagal,
+// semua dibatalkan dan tidak ada gambar barcode yang ditulis.
+func bulkInsertAtomic(ctx context.Context, products []models.Product, userID uint, response *models.BulkInsertResponse) {
+	failed := -1
+	err := barcodeTransaction(ctx, func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error {
+		for i := range products {
+			err := prepareProduct(tx, &products[i], barcodes)
+			if err == nil {
+				err = createProduct(tx, &products[i], userID, "")
+			}
+			if err != nil {
+				failed = i
+				response.Results[i].Status = models.BulkFailed
+				response.Results[i].Error = bulkRowError(err)
+				return errBulkRollback
+			}
+		}
+		return nil
+	})
+
+	// Jika tidak ada baris yang gagal tetapi transaksi tetap gagal (misalnya saat commit),
+	// alasannya dicantumkan di setiap baris agar klien tahu kenapa semuanya dibatalkan
+	if err != nil && failed == -1 {
+		log.Printf("Bulk insert transaction failed: %v", err)
+	}
+	for i := range products {
+		if err == nil {
+			response.Results[i].Status = models.BulkCreated
+			response.Results[i].SKU = products[i].SKU
+			response.Results[i].ProductID = products[i].ID
+			continue
+		}
+
+		if i != failed {
+			response.Results[i].Status = models.BulkRolledBack
+		}
+		if failed == -1 {
+			response.Results[i].Error = bulkRowError(err)
+		}
+	}
+}
+
+// bulkInsertEach menyimpan setiap produk valid dalam transaksinya sendiri
+func bulkInsertEach(ctx context.Context, products []models.Product, valid []bool, userID uint, response *models.BulkInsertResponse) {
+	for i := range products {
+		if !valid[i] {
+			continue
+		}
+
+		err := barcodeTransaction(ctx, func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error {
+			if err := prepareProduct(tx, &products[i], barcodes); err != nil {
+				return err
+			}
+			return createProduct(tx, &products[i], userID, "")
+		})
+		if err != nil {
+			response.Results[i].Status = models.BulkFailed
+			response.Results[i].Error = bulkRowError(err)
+			continue
+		}
+
+		response.Results[i].Status = models.BulkCreated
+		response.Results[i].SKU = products[i].SKU
+		response.Results[i].ProductID = products[i].ID
+	}
+}
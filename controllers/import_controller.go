@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"warehouse-backend/filestore"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxImportSize membatasi ukuran file CSV yang diimpor
+const maxImportSize = 10 << 20
+
+// errDryRun dipakai untuk membatalkan transaksi impor pada mode dry run
+var errDryRun = errors.New("dry run")
+
+// errImportRow adalah alasan penolakan satu baris CSV yang dikirim ke klien
+type errImportRow struct{ reason string }
+
+func (e errImportRow) Error() string { return e.reason }
+
+// importRecord adalah satu baris CSV yang sudah dipetakan ke kolom ekspor.
+// Kolom yang tidak ada di header bernilai nil sehingga tidak mengubah data lama.
+type importRecord struct {
+	Name     *string
+	SKU      string
+	Quantity *string
+	Location *string
+}
+
+// importColumns membaca header CSV (tidak peka huruf besar) menjadi indeks kolom.
+// Kolom ID, Status dan BarcodePath dari file ekspor diabaikan karena dihitung ulang.
+func importColumns(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+
+	_, hasName := columns["name"]
+	_, hasSKU := columns["sku"]
+	if !hasName && !hasSKU {
+		return nil, errors.New("CSV header must contain at least Name or SKU")
+	}
+	return columns, nil
+}
+
+// parseImportRecord memetakan satu baris CSV berdasarkan header
+func parseImportRecord(columns map[string]int, row []string) importRecord {
+	field := func(name string) *string {
+		i, ok := columns[name]
+		if !ok {
+			return nil
+		}
+		value := ""
+		if i < len(row) {
+			value = strings.TrimSpace(row[i])
+		}
+		return &value
+	}
+
+	record := importRecord{
+		Name:     field("name"),
+		Quantity: field("quantity"),
+		Location: field("location"),
+	}
+	if sku := field("sku"); sku != nil {
+		record.SKU = *sku
+	}
+	return record
+}
+
+// importProductRow membuat atau memperbarui satu produk berdasarkan SKU
+func importProductRow(tx *gorm.DB, record importRecord, userID uint, reference string, barcodes *filestore.BarcodeBatch) (models.ImportRowResult, error) {
+	result := models.ImportRowResult{SKU: record.SKU}
+
+	var quantity *int
+	if record.Quantity != nil && *record.Quantity != "" {
+		n, err := strconv.Atoi(*record.Quantity)
+		if err != nil {
+			return result, errImportRow{"Quantity must be a whole number"}
+		}
+		if n < 0 {
+			return result, errImportRow{"Quantity cannot be negative"}
+		}
+		quantity = &n
+	}
+
+	var product models.Product
+	found := false
+	if record.SKU != "" {
+		err := tx.Unscoped().Where("sku = ?", record.SKU).First(&product).Error
+		switch {
+		case err == nil:
+			found = true
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return result, err
+		}
+		if found && product.DeletedAt.Valid {
+			return result, errImportRow{"SKU belongs to a deleted product"}
+		}
+	}
+
+	if !found {
+		if record.Name == nil || *record.Name == "" {
+			return result, errImportRow{"Name is required for new products"}
+		}
+
+		product = models.Product{Name: *record.Name, SKU: record.SKU}
+		if record.Location != nil {
+			product.Location = *record.Location
+		}
+		if quantity != nil {
+			product.Quantity = *quantity
+		}
+		// Validasi, SKU dan barcode sama seperti POST /products. Gambar barcode tidak ditulis pada
+		// dry run karena transaksinya dibatalkan, tetapi tetap dirender agar SKU yang tidak bisa
+		// di-encode ditolak.
+		if err := prepareProduct(tx, &product, barcodes); err != nil {
+			var invalid errInvalidProduct
+			switch {
+			case errors.As(err, &invalid):
+				return result, errImportRow{invalid.reason}
+			case errors.Is(err, errSKUExists):
+				return result, errImportRow{"SKU already exists"}
+			}
+			return result, err
+		}
+
+		if err := createProduct(tx, &product, userID, reference); err != nil {
+			if errors.Is(err, errSKUExists) {
+				return result, errImportRow{"SKU already exists"}
+			}
+			return result, err
+		}
+
+		result.Action = models.ImportCreated
+		result.SKU = product.SKU
+		result.ProductID = product.ID
+		return result, nil
+	}
+
+	if err := lockProduct(tx, product.ID, &product); err != nil {
+		return result, err
+	}
+	result.ProductID = product.ID
+
+	current := product
+	if record.Name != nil && *record.Name != "" {
+		product.Name = *record.Name
+	}
+	if record.Location != nil {
+		product.Location = *record.Location
+	}
+
+	// Quantity di file adalah stok total; selisihnya dicatat sebagai penyesuaian di gudang default
+	// dengan kebijakan stok negatif produk, sama seperti PUT /products/{id}/stock
+	delta := 0
+	if quantity != nil {
+		delta = *quantity - current.Quantity
+	}
+
+	if delta == 0 && product.Name == current.Name && product.Location == current.Location {
+		result.Action = models.ImportUnchanged
+		return result, nil
+	}
+
+	product.Version = current.Version + 1
+	if delta != 0 {
+		adjusted, err := adjustStock(tx, stockChange{
+			ProductID: product.ID,
+			Change:    delta,
+			Reason:    models.MovementReasonAdjustment,
+			Reference: reference,
+			UserID:    userID,
+		})
+		var stockErr *InsufficientStockError
+		if errors.As(err, &stockErr) {
+			return result, errImportRow{fmt.Sprintf("Insufficient stock in default warehouse: available %d, requested %d", stockErr.Available, stockErr.Requested)}
+		}
+		if err != nil {
+			return result, err
+		}
+		// adjustStock sudah menaikkan versi dan menyimpan quantity baru
+		adjusted.Product.Name = product.Name
+		adjusted.Product.Location = product.Location
+		product = adjusted.Product
+	}
+	if err := tx.Save(&product).Error; err != nil {
+		return result, err
+	}
+
+	result.Action = models.ImportUpdated
+	return result, nil
+}
+
+// ImportProductsCSV godoc
+// @Summary Impor produk dari CSV
+// @Description Mengimpor file CSV dengan format yang sama seperti /products/export (ID, Name, SKU, Quantity, Location, Status, BarcodePath).
+// @Description Baris dengan SKU yang sudah ada diperbarui, baris lain dibuat sebagai produk baru dengan SKU dan barcode otomatis
+// @Description jika SKU kosong. Kolom ID, Status dan BarcodePath diabaikan. Baris yang gagal ditolak tanpa membatalkan baris lain.
+// @Description Dengan dry_run=true tidak ada perubahan yang disimpan.
+// @Tags Products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File CSV"
+// @Param dry_run query bool false "Validasi tanpa menyimpan"
+// @Success 200 {object} models.ImportReport
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /products/import [post]
+func ImportProductsCSV(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", c.PostForm("dry_run")))
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "CSV file is required in field 'file'"})
+		return
+	}
+	if header.Size > maxImportSize {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "CSV file is larger than 10 MB"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Failed to read CSV file"})
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	firstRow, err := reader.Read()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "CSV file is empty or invalid"})
+		return
+	}
+	columns, err := importColumns(firstRow)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	report := models.ImportReport{DryRun: dryRun, Rows: []models.ImportRowResult{}}
+	reference := "import:" + header.Filename
+	userID := middleware.CurrentUserID(c)
+
+	err = barcodeTransaction(c.Request.Context(), func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error {
+		seen := map[string]int{}
+
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				report.Add(models.ImportRowResult{Line: parseErr.Line, Action: models.ImportRejected, Error: parseErr.Err.Error()})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			line, _ := reader.FieldPos(0)
+			record := parseImportRecord(columns, row)
+			if first, ok := seen[record.SKU]; ok && record.SKU != "" {
+				report.Add(models.ImportRowResult{
+					Line:   line,
+					SKU:    record.SKU,
+					Action: models.ImportRejected,
+					Error:  "Duplicate SKU, already used on line " + strconv.Itoa(first),
+				})
+				continue
+			}
+			seen[record.SKU] = line
+
+			// Setiap baris memakai savepoint agar baris yang gagal tidak membatalkan baris lain.
+			// Barcode baris hanya ikut ditulis jika savepoint-nya berhasil.
+			var result models.ImportRowResult
+			var rowBarcodes filestore.BarcodeBatch
+			err = tx.Transaction(func(rowTx *gorm.DB) error {
+				var err error
+				result, err = importProductRow(rowTx, record, userID, reference, &rowBarcodes)
+				return err
+			})
+			if err == nil {
+				barcodes.Merge(rowBarcodes)
+			} else {
+				var rowErr errImportRow
+				if !errors.As(err, &rowErr) {
+					rowErr = errImportRow{"Failed to save row"}
+				}
+				result = models.ImportRowResult{SKU: record.SKU, Action: models.ImportRejected, Error: rowErr.reason}
+			}
+			result.Line = line
+			report.Add(result)
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to import products"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultInviteHours = 72
+
+var (
+	errRegistrationDisabled = errors.New("registration is disabled")
+	errInviteRequired       = errors.New("invite code required")
+	errInvalidInvite        = errors.New("invalid invite code")
+)
+
+// registrationMode membaca REGISTRATION_MODE, default invite
+func registrationMode() string {
+	mode := utils.GetEnv("REGISTRATION_MODE", models.RegistrationInvite)
+	switch mode {
+	case models.RegistrationOpen, models.RegistrationInvite, models.RegistrationDisabled:
+		return mode
+	}
+	return models.RegistrationInvite
+}
+
+// findUsableInvite mengunci undangan berdasarkan kode dan memastikan masih bisa dipakai untuk email tersebut
+func findUsableInvite(tx *gorm.DB, code, email string) (*models.Invite, error) {
+	var invite models.Invite
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("code_hash = ?", utils.HashToken(code)).
+		First(&invite).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidInvite
+		}
+		return nil, err
+	}
+
+	if !invite.Usable(time.Now()) || !strings.EqualFold(invite.Email, strings.TrimSpace(email)) {
+		return nil, errInvalidInvite
+	}
+	return &invite, nil
+}
+
+// CreateInvite godoc
+// @Summary Buat undangan registrasi
+// @Description Membuat kode undangan untuk email dan role tertentu (khusus admin). Kode hanya ditampilkan sekali.
+// @Tags Invites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param invite body models.InviteSwagger true "Invite JSON"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /invites [post]
+func CreateInvite(c *gin.Context) {
+	var payload models.InviteSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload.Email = strings.TrimSpace(payload.Email)
+	if payload.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email is required"})
+		return
+	}
+	if payload.Role == "" {
+		payload.Role = models.RoleViewer
+	}
+	if !models.IsValidRole(payload.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+	if payload.ExpiresInHours <= 0 {
+		payload.ExpiresInHours = defaultInviteHours
+	}
+
+	code, err := utils.RandomToken(24)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate invite code"})
+		return
+	}
+
+	invite := models.Invite{
+		Email:     payload.Email,
+		Role:      payload.Role,
+		CodeHash:  utils.HashToken(code),
+		ExpiresAt: time.Now().Add(time.Duration(payload.ExpiresInHours) * time.Hour),
+		CreatedBy: middleware.CurrentUserID(c),
+	}
+	if err := database.DB.Create(&invite).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invite"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"invite": invite, "code": code})
+}
+
+// GetInvites godoc
+// @Summary Daftar undangan
+// @Tags Invites
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Invite
+// @Failure 403 {object} map[string]string
+// @Router /invites [get]
+func GetInvites(c *gin.Context) {
+	invites := []models.Invite{}
+	database.DB.Order("created_at DESC").Find(&invites)
+	c.JSON(http.StatusOK, invites)
+}
+
+// DeleteInvite godoc
+// @Summary Cabut undangan
+// @Tags Invites
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invite ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /invites/{id} [delete]
+func DeleteInvite(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var invite models.Invite
+	if err := database.DB.First(&invite, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invite not found"})
+		return
+	}
+
+	if err := database.DB.Delete(&invite).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete invite"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invite revoked"})
+}
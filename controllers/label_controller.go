@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxLabels membatasi jumlah label (termasuk salinan) dalam satu request
+const maxLabels = 5000
+
+// errLabelRequest adalah kesalahan input label yang dikirim ke klien apa adanya
+type errLabelRequest struct {
+	status int
+	reason string
+}
+
+func (e errLabelRequest) Error() string { return e.reason }
+
+// labelTemplate memilih template label dari request, default DefaultLabelTemplate
+func labelTemplate(name string) (utils.LabelTemplate, error) {
+	if name == "" {
+		name = utils.DefaultLabelTemplate
+	}
+	template, ok := utils.LabelTemplates[strings.ToLower(name)]
+	if !ok {
+		return template, errLabelRequest{http.StatusBadRequest, fmt.Sprintf("Unknown label template %q", name)}
+	}
+	return template, nil
+}
+
+// findLabelProduct mencari produk berdasarkan ID atau, jika ID kosong, SKU
+func findLabelProduct(db *gorm.DB, id uint, sku string) (models.Product, error) {
+	var product models.Product
+	var err error
+	switch {
+	case id != 0:
+		err = db.First(&product, id).Error
+	case sku != "":
+		err = db.Where("sku = ?", sku).First(&product).Error
+	default:
+		return product, errLabelRequest{http.StatusBadRequest, "product_id or sku is required"}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return product, errLabelRequest{http.StatusNotFound, "Product not found"}
+	}
+	return product, err
+}
+
+// resolveLabelItems mencari produk setiap item dan mengulanginya sebanyak Copies, dengan urutan request
+func resolveLabelItems(db *gorm.DB, items []models.LabelItem) ([]utils.LabelContent, error) {
+	if len(items) == 0 {
+		return nil, errLabelRequest{http.StatusBadRequest, "At least one item is required"}
+	}
+
+	labels := []utils.LabelContent{}
+	for i, item := range items {
+		copies := item.Copies
+		if copies == 0 {
+			copies = 1
+		}
+		if copies < 0 {
+			return nil, errLabelRequest{http.StatusBadRequest, fmt.Sprintf("Item %d: copies cannot be negative", i+1)}
+		}
+		if len(labels)+copies > maxLabels {
+			return nil, errLabelRequest{http.StatusBadRequest, fmt.Sprintf("A request can print at most %d labels", maxLabels)}
+		}
+
+		product, err := findLabelProduct(db, item.ProductID, item.SKU)
+		if err != nil {
+			var labelErr errLabelRequest
+			if errors.As(err, &labelErr) {
+				labelErr.reason = fmt.Sprintf("Item %d: %s", i+1, labelErr.reason)
+				return nil, labelErr
+			}
+			return nil, err
+		}
+
+		label := utils.LabelContent{Name: product.Name, SKU: product.SKU, Location: product.Location}
+		for n := 0; n < copies; n++ {
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
+
+// PrintLabels godoc
+// @Summary Cetak label barcode sebagai PDF
+// @Description Membuat PDF label untuk daftar produk (product_id atau sku) dengan jumlah salinan per produk.
+// @Description Setiap label berisi nama produk, barcode (vektor), SKU dan lokasi. Template lembar Avery menaruh banyak
+// @Description label per halaman; template thermal mencetak satu label per halaman. start_at (default 1) adalah posisi label
+// @Description pertama pada lembar pertama untuk melewati label yang sudah terpakai, border menggambar garis tepi untuk mengecek posisi cetak.
+// @Tags Labels
+// @Accept json
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param request body models.LabelRequest true "Produk dan template label"
+// @Success 200 {file} file "PDF label"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /labels [post]
+func PrintLabels(c *gin.Context) {
+	var req models.LabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	template, err := labelTemplate(req.Template)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+	startAt := 1
+	if req.StartAt != nil {
+		startAt = *req.StartAt
+	}
+	if startAt < 1 || startAt > template.PerPage() {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: fmt.Sprintf("start_at must be between 1 and %d for this template", template.PerPage())})
+		return
+	}
+
+	symbology := strings.ToLower(req.Symbology)
+	if symbology == "" {
+		symbology = utils.DefaultSymbology()
+	}
+	if !utils.IsValidSymbology(symbology) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: fmt.Sprintf("Unsupported symbology %q", req.Symbology)})
+		return
+	}
+
+	labels, err := resolveLabelItems(database.DB, req.Items)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	opts := utils.LabelOptions{Symbology: symbology, StartAt: startAt, Border: req.Border}
+	if err := utils.WriteLabelsPDF(&buf, template, labels, opts); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Cannot render labels: " + err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=labels-"+template.Name+".pdf")
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// GetLabelTemplates godoc
+// @Summary Daftar template label
+// @Description Mengembalikan template label yang tersedia beserta ukurannya dalam milimeter
+// @Tags Labels
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} utils.LabelTemplate
+// @Router /labels/templates [get]
+func GetLabelTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.SortedLabelTemplates())
+}
+
+// respondLabelError memetakan error label ke status HTTP
+func respondLabelError(c *gin.Context, err error) {
+	var labelErr errLabelRequest
+	if errors.As(err, &labelErr) {
+		c.JSON(labelErr.status, models.ErrorResponse{Error: labelErr.reason})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load products"})
+}
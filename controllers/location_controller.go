@@ -0,0 +1,346 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/filestore"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// errLocationCodeTaken dikembalikan jika kode lokasi sudah dipakai sebagai SKU atau barcode produk
+var errLocationCodeTaken = errors.New("location code is already used by a product")
+
+// locationCodeTaken memeriksa apakah kode lokasi sudah dipakai sebagai SKU produk (termasuk yang
+// sudah dihapus) atau barcode alternatif. SKU berbagi key gambar barcode dengan lokasi dan ketiganya
+// dicocokkan saat scan, jadi perbandingannya tidak membedakan huruf besar.
+func locationCodeTaken(tx *gorm.DB, code string) (bool, error) {
+	var count int64
+	err := tx.Unscoped().Model(&models.Product{}).Where("UPPER(sku) = ?", code).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = tx.Model(&models.ProductBarcode{}).Where("UPPER(code) = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// CreateLocation godoc
+// @Summary Tambah lokasi (zone / aisle / rack / shelf / bin)
+// @Description Membuat lokasi di dalam gudang. Kode lokasi dibentuk dari kode gudang dan komponennya, lalu barcode lokasi dibuat otomatis.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param location body models.LocationSwagger true "Location JSON"
+// @Success 201 {object} models.Location
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /locations [post]
+func CreateLocation(c *gin.Context) {
+	var payload models.LocationSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var warehouse models.Warehouse
+	if err := database.DB.First(&warehouse, payload.WarehouseID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Warehouse not found"})
+		return
+	}
+
+	location := models.Location{
+		WarehouseID: warehouse.ID,
+		Zone:        payload.Zone,
+		Aisle:       payload.Aisle,
+		Rack:        payload.Rack,
+		Shelf:       payload.Shelf,
+		Bin:         payload.Bin,
+		Description: payload.Description,
+	}
+	if err := location.Normalize(); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+	location.BuildCode(warehouse.Code)
+
+	// Lokasi yang pernah dihapus dengan kode sama dipulihkan agar riwayat ledger tetap tersambung
+	var existing models.Location
+	err := database.DB.Unscoped().Where("code = ?", location.Code).First(&existing).Error
+	if err == nil {
+		if !existing.DeletedAt.Valid {
+			c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Location already exists"})
+			return
+		}
+		location.ID = existing.ID
+		location.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Database error"})
+		return
+	}
+
+	err = barcodeTransaction(c.Request.Context(), func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error {
+		// Kunci bersama pada gudang mencegah gudang dihapus saat lokasi ini dibuat
+		if _, err := findWarehouse(tx, warehouse.ID); err != nil {
+			return err
+		}
+		taken, err := locationCodeTaken(tx, location.Code)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errLocationCodeTaken
+		}
+		key, err := barcodes.Generate(location.Code)
+		if err != nil {
+			return err
+		}
+		location.BarcodePath = key
+		return tx.Unscoped().Save(&location).Error
+	})
+	if errors.Is(err, filestore.ErrBarcodeEncode) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, errWarehouseNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Warehouse not found"})
+		return
+	}
+	if errors.Is(err, errLocationCodeTaken) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Location code is already used by a product"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to create location"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, location)
+}
+
+// GetLocations godoc
+// @Summary Daftar lokasi
+// @Description Mengambil lokasi terurut berdasarkan zone, aisle, rack, shelf dan bin
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Param warehouse_id query int false "Filter per gudang"
+// @Param zone query string false "Filter zone"
+// @Param aisle query string false "Filter aisle"
+// @Param q query string false "Awalan kode lokasi"
+// @Success 200 {array} models.Location
+// @Failure 400 {object} models.ErrorResponse
+// @Router /locations [get]
+func GetLocations(c *gin.Context) {
+	query := database.DB.Model(&models.Location{})
+
+	if value := c.Query("warehouse_id"); value != "" {
+		warehouseID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid warehouse_id"})
+			return
+		}
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+	if zone := c.Query("zone"); zone != "" {
+		query = query.Where("zone = ?", strings.ToUpper(zone))
+	}
+	if aisle := c.Query("aisle"); aisle != "" {
+		query = query.Where("aisle = ?", strings.ToUpper(aisle))
+	}
+	if q := c.Query("q"); q != "" {
+		query = query.Where("code LIKE ?", strings.ToUpper(q)+"%")
+	}
+
+	locations := []models.Location{}
+	query.Order("warehouse_id, zone, aisle, rack, shelf, bin").Find(&locations)
+	c.JSON(http.StatusOK, locations)
+}
+
+// GetLocationByID godoc
+// @Summary Ambil lokasi berdasarkan ID
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Location ID"
+// @Success 200 {object} models.Location
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /locations/{id} [get]
+func GetLocationByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var location models.Location
+	if err := database.DB.Preload("Warehouse").First(&location, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Location not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+// UpdateLocation godoc
+// @Summary Update lokasi
+// @Description Hanya deskripsi yang dapat diubah; kode lokasi tetap agar label yang sudah tercetak tetap valid
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Location ID"
+// @Param location body models.LocationSwagger true "Location JSON"
+// @Success 200 {object} models.Location
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /locations/{id} [put]
+func UpdateLocation(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var location models.Location
+	if err := database.DB.First(&location, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Location not found"})
+		return
+	}
+
+	var payload models.LocationSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	location.Description = payload.Description
+	if err := database.DB.Save(&location).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update location"})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+// DeleteLocation godoc
+// @Summary Hapus lokasi
+// @Description Menghapus lokasi yang sudah tidak menyimpan stok
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Location ID"
+// @Success 200 {object} models.DeleteProductResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /locations/{id} [delete]
+func DeleteLocation(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var location models.Location
+	if err := database.DB.First(&location, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Location not found"})
+		return
+	}
+
+	var stocked int64
+	database.DB.Model(&models.LocationStock{}).
+		Where("location_id = ? AND quantity <> 0", location.ID).
+		Count(&stocked)
+	if stocked > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Location still holds stock"})
+		return
+	}
+
+	if err := database.DB.Delete(&location).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to delete location"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeleteProductResponse{Message: "Location deleted successfully"})
+}
+
+// GetLocationBarcode godoc
+// @Summary Ambil barcode lokasi
+// @Description Mengembalikan gambar barcode kode lokasi untuk dicetak dan ditempel di rak
+// @Tags Locations
+// @Produce png
+// @Security BearerAuth
+// @Param id path int true "Location ID"
+// @Success 200
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} models.ErrorResponse
+// @Router /locations/{id}/barcode [get]
+func GetLocationBarcode(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var location models.Location
+	if err := database.DB.First(&location, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lokasi tidak ditemukan"})
+		return
+	}
+
+	reader, info, err := openStoredBarcode(c, location.Code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load barcode"})
+		return
+	}
+	if key := filestore.BarcodeKey(location.Code); location.BarcodePath != key {
+		if err := database.DB.Model(&location).UpdateColumn("barcode_path", key).Error; err != nil {
+			reader.Close()
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load barcode"})
+			return
+		}
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s.png", location.Code))
+	sendStoredBarcode(c, reader, info)
+}
+
+// GetLocationStock godoc
+// @Summary Stok per lokasi
+// @Description Mengambil semua produk yang tersimpan di sebuah lokasi
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Location ID"
+// @Success 200 {array} models.LocationStock
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /locations/{id}/stock [get]
+func GetLocationStock(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var location models.Location
+	if err := database.DB.First(&location, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Location not found"})
+		return
+	}
+
+	stocks := []models.LocationStock{}
+	database.DB.Preload("Product").
+		Joins("JOIN products ON products.id = location_stocks.product_id AND products.deleted_at IS NULL").
+		Where("location_stocks.location_id = ? AND location_stocks.quantity <> 0", location.ID).
+		Order("products.name ASC").
+		Find(&stocks)
+
+	c.JSON(http.StatusOK, stocks)
+}
@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxPackQuantity membatasi isi satu kemasan agar salah ketik tidak mengubah stok secara besar-besaran
+const maxPackQuantity = 100000
+
+// errBarcodeExists dikembalikan jika kode sudah dipakai sebagai barcode atau SKU produk mana pun, atau sebagai kode lokasi
+var errBarcodeExists = errors.New("barcode already exists")
+
+// normalizeProductBarcode merapikan dan memvalidasi barcode alternatif. Symbology kosong ditebak:
+// kode numerik 8/12/13/14 digit menjadi ean8/upca/ean13/itf14, kode lain code128. Kode GS1 wajib memiliki
+// check digit yang benar dan disimpan juga sebagai GTIN-14 untuk pencocokan bentuk yang setara.
+func normalizeProductBarcode(barcode *models.ProductBarcode) error {
+	barcode.Code = strings.TrimSpace(barcode.Code)
+	barcode.Symbology = strings.ToLower(strings.TrimSpace(barcode.Symbology))
+	barcode.Description = strings.TrimSpace(barcode.Description)
+	barcode.GTIN = nil
+
+	if barcode.Code == "" {
+		return errInvalidProduct{"Code is required"}
+	}
+	if len(barcode.Code) > 100 || strings.IndexFunc(barcode.Code, func(r rune) bool { return unicode.IsSpace(r) || !unicode.IsPrint(r) }) >= 0 {
+		return errInvalidProduct{"Code must be at most 100 printable characters without spaces"}
+	}
+	if len(barcode.Description) > 100 {
+		return errInvalidProduct{"Description must be at most 100 characters"}
+	}
+
+	if barcode.Symbology == "" {
+		// Kode numerik sepanjang GTIN dianggap GTIN sehingga check digit yang salah tetap ditolak
+		barcode.Symbology = utils.SymbologyCode128
+		if strings.Trim(barcode.Code, "0123456789") == "" && utils.GTINSymbology(barcode.Code) != "" {
+			barcode.Symbology = utils.GTINSymbology(barcode.Code)
+		}
+	}
+
+	if length := utils.GTINLength(barcode.Symbology); length > 0 {
+		if len(barcode.Code) != length || strings.Trim(barcode.Code, "0123456789") != "" {
+			return errInvalidProduct{fmt.Sprintf("%s codes must have exactly %d digits", barcode.Symbology, length)}
+		}
+		if !utils.ValidGTIN(barcode.Code) {
+			return errInvalidProduct{"Invalid GTIN check digit"}
+		}
+		gtin := utils.GTIN14(barcode.Code)
+		barcode.GTIN = &gtin
+	} else if !utils.IsValidSymbology(barcode.Symbology) {
+		return errInvalidProduct{fmt.Sprintf("Unsupported symbology %q", barcode.Symbology)}
+	}
+
+	if barcode.PackQuantity == 0 {
+		barcode.PackQuantity = 1
+	}
+	if barcode.PackQuantity < 1 || barcode.PackQuantity > maxPackQuantity {
+		return errInvalidProduct{fmt.Sprintf("pack_quantity must be between 1 and %d", maxPackQuantity)}
+	}
+	return nil
+}
+
+// barcodeTaken memeriksa apakah kode (atau GTIN yang setara) sudah dipakai barcode lain atau
+// SKU produk mana pun, termasuk produk yang sudah dihapus. excludeID adalah barcode yang sedang diubah.
+func barcodeTaken(tx *gorm.DB, barcode models.ProductBarcode, excludeID uint) (bool, error) {
+	query := tx.Model(&models.ProductBarcode{}).Where("id <> ?", excludeID)
+	if barcode.GTIN != nil {
+		query = query.Where("code = ? OR gtin = ?", barcode.Code, *barcode.GTIN)
+	} else {
+		query = query.Where("code = ?", barcode.Code)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	skus := []string{barcode.Code}
+	if barcode.GTIN != nil {
+		skus = append(skus, utils.GTINVariants(barcode.Code)...)
+	}
+	err := tx.Unscoped().Model(&models.Product{}).Where("sku IN ?", skus).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	// Kode lokasi juga dicocokkan saat scan, jadi barcode yang sama akan membuat hasil scan ambigu
+	err = tx.Unscoped().Model(&models.Location{}).Where("code = ?", strings.ToUpper(barcode.Code)).Count(&count).Error
+	return count > 0, err
+}
+
+// bindProductBarcode membaca body, memvalidasi barcode dan memastikan produknya ada
+func bindProductBarcode(c *gin.Context, productID uint) (models.ProductBarcode, bool) {
+	var payload models.ProductBarcodeSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return models.ProductBarcode{}, false
+	}
+
+	barcode := models.ProductBarcode{
+		ProductID:    productID,
+		Code:         payload.Code,
+		Symbology:    payload.Symbology,
+		PackQuantity: payload.PackQuantity,
+		Description:  payload.Description,
+	}
+	if err := normalizeProductBarcode(&barcode); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return barcode, false
+	}
+
+	var product models.Product
+	if err := database.DB.First(&product, productID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
+		return barcode, false
+	}
+	return barcode, true
+}
+
+// respondBarcodeSaveError memetakan error simpan barcode ke respons HTTP
+func respondBarcodeSaveError(c *gin.Context, err error) {
+	// ErrDuplicatedKey berasal dari unique index code/gtin saat dua request serentak lolos barcodeTaken
+	if errors.Is(err, errBarcodeExists) || errors.Is(err, gorm.ErrDuplicatedKey) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Barcode is already used by a product or location"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save barcode"})
+}
+
+// GetProductBarcodes godoc
+// @Summary Daftar barcode alternatif produk
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {array} models.ProductBarcode
+// @Failure 404 {object} models.ErrorResponse
+// @Router /products/{id}/barcodes [get]
+func GetProductBarcodes(c *gin.Context) {
+	productID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var product models.Product
+	if err := database.DB.Preload("Barcodes").First(&product, productID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
+		return
+	}
+	if product.Barcodes == nil {
+		product.Barcodes = []models.ProductBarcode{}
+	}
+	c.JSON(http.StatusOK, product.Barcodes)
+}
+
+// AddProductBarcode godoc
+// @Summary Tambah barcode alternatif produk
+// @Description Menambahkan barcode pabrik (EAN/UPC) atau barcode karton. Kode GS1 (ean8, upca, ean13, itf14) divalidasi check
+// @Description digit-nya; symbology kosong ditebak dari kode. Kode harus unik di semua produk dan lokasi, termasuk terhadap SKU, kode lokasi dan bentuk
+// @Description GTIN yang setara. pack_quantity adalah jumlah unit yang dihitung setiap kali barcode ini di-scan.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param barcode body models.ProductBarcodeSwagger true "Barcode"
+// @Success 201 {object} models.ProductBarcode
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /products/{id}/barcodes [post]
+func AddProductBarcode(c *gin.Context) {
+	productID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	barcode, ok := bindProductBarcode(c, productID)
+	if !ok {
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		taken, err := barcodeTaken(tx, barcode, 0)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errBarcodeExists
+		}
+		return tx.Create(&barcode).Error
+	})
+	if err != nil {
+		respondBarcodeSaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, barcode)
+}
+
+// UpdateProductBarcode godoc
+// @Summary Ubah barcode alternatif produk
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param barcodeId path int true "Barcode ID"
+// @Param barcode body models.ProductBarcodeSwagger true "Barcode"
+// @Success 200 {object} models.ProductBarcode
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /products/{id}/barcodes/{barcodeId} [put]
+func UpdateProductBarcode(c *gin.Context) {
+	productID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	barcodeID, ok := parseIDParam(c, "barcodeId")
+	if !ok {
+		return
+	}
+
+	var existing models.ProductBarcode
+	if err := database.DB.Where("product_id = ?", productID).First(&existing, barcodeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Barcode not found"})
+		return
+	}
+	barcode, ok := bindProductBarcode(c, productID)
+	if !ok {
+		return
+	}
+	barcode.ID = existing.ID
+	barcode.CreatedAt = existing.CreatedAt
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		taken, err := barcodeTaken(tx, barcode, barcode.ID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errBarcodeExists
+		}
+		return tx.Save(&barcode).Error
+	})
+	if err != nil {
+		respondBarcodeSaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, barcode)
+}
+
+// DeleteProductBarcode godoc
+// @Summary Hapus barcode alternatif produk
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param barcodeId path int true "Barcode ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /products/{id}/barcodes/{barcodeId} [delete]
+func DeleteProductBarcode(c *gin.Context) {
+	productID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	barcodeID, ok := parseIDParam(c, "barcodeId")
+	if !ok {
+		return
+	}
+
+	result := database.DB.Where("product_id = ?", productID).Delete(&models.ProductBarcode{}, barcodeID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to delete barcode"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Barcode not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Barcode deleted"})
+}
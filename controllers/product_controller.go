@@ -2,59 +2,54 @@ package controllers
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"warehouse-backend/database"
+	"warehouse-backend/filestore"
+	"warehouse-backend/middleware"
 	"warehouse-backend/models"
 	"warehouse-backend/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// BulkInsertProducts godoc
-// @Summary Menambahkan banyak produk sekaligus
-// @Description Menyimpan data produk dalam jumlah banyak menggunakan JSON array
-// @Tags Products
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param products body []models.ProductSwagger true "Daftar Produk"
-// @Success 201 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /products/bulk [post]
-func BulkInsertProducts(c *gin.Context) {
-	var products []models.Product
+// errVersionConflict menandakan versi produk tidak sama dengan yang diharapkan klien
+var errVersionConflict = errors.New("product version conflict")
 
-	// Parse JSON request body
-	if err := c.ShouldBindJSON(&products); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+// errInvalidBody membungkus error parsing body yang terjadi di dalam transaksi
+type errInvalidBody struct{ err error }
 
-	// Validasi: Pastikan data tidak kosong
-	if len(products) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Data produk tidak boleh kosong"})
-		return
-	}
+func (e errInvalidBody) Error() string { return e.err.Error() }
 
-	// Insert ke database
-	if err := database.DB.Create(&products).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menyimpan produk"})
-		return
-	}
+// productETag membentuk nilai ETag dari versi produk
+func productETag(product models.Product) string {
+	return fmt.Sprintf("\"%d\"", product.Version)
+}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Produk berhasil ditambahkan"})
+// parseETag membaca versi dari header If-Match, misalnya "3" atau W/"3"
+func parseETag(value string) (uint, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+	version, err := strconv.ParseUint(strings.Trim(value, "\""), 10, 64)
+	return uint(version), err
 }
 
 type StockUpdateRequest struct {
-	Change int `json:"change"`
+	Change      int    `json:"change" example:"-5"`
+	Reason      string `json:"reason" example:"pick"`
+	Reference   string `json:"reference" example:"SO-2024-0001"`
+	WarehouseID uint   `json:"warehouse_id" example:"1"`
+	LocationID  uint   `json:"location_id" example:"1"`
 }
 
 // UpdateStock godoc
 // @Summary Update stock of a product
-// @Description Change the quantity of a product by a specified amount
+// @Description Change the quantity of a product by a specified amount and record it in the stock movement ledger.
+// @Description Decrements beyond the available quantity follow the product's negative stock policy (reject, allow or clamp).
 // @Tags Products
 // @Accept json
 // @Produce json
@@ -63,48 +58,48 @@ type StockUpdateRequest struct {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /products/{id}/stock [put]
 // @Security BearerAuth
 func UpdateStock(c *gin.Context) {
-	id := c.Param("id")
-	var request StockUpdateRequest
-
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	id, ok := parseIDParam(c, "id")
+	if !ok {
 		return
 	}
 
-	var product models.Product
-	db := database.GetDB()
-	if err := db.First(&product, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+	request, ok := bindStockRequest(c)
+	if !ok {
 		return
 	}
 
-	product.Quantity += request.Change
-	if product.Quantity < 0 {
-		product.Quantity = 0
-	}
-	if product.Quantity == 0 {
-		product.Status = "Out of Stock"
-	} else if product.Quantity > 0 && product.Quantity < 10 {
-		product.Status = "Low Stock"
-	} else {
-		product.Status = "Available"
-	}
-
-	if err := db.Save(&product).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update stock"})
+	var result stockResult
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = adjustStock(tx, stockChange{
+			ProductID:   id,
+			WarehouseID: request.WarehouseID,
+			LocationID:  request.LocationID,
+			Change:      request.Change,
+			Reason:      request.Reason,
+			Reference:   request.Reference,
+			UserID:      middleware.CurrentUserID(c),
+		})
+		return err
+	})
+	if err != nil {
+		respondStockError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Stock updated", "quantity": product.Quantity})
+	c.Header("ETag", productETag(result.Product))
+	c.JSON(http.StatusOK, stockResponse(result))
 }
 
 // CreateProduct godoc
 // @Summary Create a new product
-// @Description Create a new product with the input payload
+// @Description Create a new product with the input payload. SKU is generated from SKU_PATTERN unless provided.
+// @Description Initial quantity goes to warehouse_id (default warehouse if empty), which also fills {WH} in the SKU.
 // @Tags Products
 // @Accept json
 // @Produce json
@@ -112,6 +107,7 @@ func UpdateStock(c *gin.Context) {
 // @Param product body models.ProductSwagger true "Product JSON"
 // @Success 201 {object} models.CreateProductResponse
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /products [post]
 func CreateProduct(c *gin.Context) {
@@ -121,46 +117,197 @@ func CreateProduct(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
 		return
 	}
-	product.Status = "Available"
-	if product.Quantity == 0 {
-		product.Status = "Out of Stock"
-	} else if product.Quantity > 0 && product.Quantity < 10 {
-		product.Status = "Low Stock"
+	err := barcodeTransaction(c.Request.Context(), func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error {
+		if err := prepareProduct(tx, &product, barcodes); err != nil {
+			return err
+		}
+		return createProduct(tx, &product, middleware.CurrentUserID(c), "")
+	})
+	if err != nil {
+		var invalid errInvalidProduct
+		switch {
+		case errors.As(err, &invalid):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: invalid.Error()})
+		case errors.Is(err, errSKUExists):
+			c.JSON(http.StatusConflict, models.ErrorResponse{Error: "SKU already exists"})
+		default:
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to create product"})
+		}
+		return
 	}
 
-	// Generate SKU otomatis
-	product.SKU = utils.GenerateSKU()
+	c.JSON(http.StatusCreated, models.CreateProductResponse{Message: "Product created successfully"})
+}
 
-	// Generate barcode
-	barcodePath, err := utils.GenerateBarcode(product.SKU)
+// errInvalidProduct adalah alasan data produk baru ditolak
+type errInvalidProduct struct{ reason string }
+
+func (e errInvalidProduct) Error() string { return e.reason }
+
+// validateNewProduct memeriksa data produk baru sebelum disimpan
+func validateNewProduct(product models.Product) error {
+	if strings.TrimSpace(product.Name) == "" {
+		return errInvalidProduct{"Name is required"}
+	}
+	if product.Quantity < 0 {
+		return errInvalidProduct{"Quantity cannot be negative"}
+	}
+	if product.NegativeStockPolicy != "" && !models.IsValidStockPolicy(product.NegativeStockPolicy) {
+		return errInvalidProduct{"Invalid negative stock policy"}
+	}
+	return nil
+}
+
+// prepareProduct memvalidasi produk baru lalu menetapkan SKU dan mencatat barcode-nya ke batch.
+// Harus dipanggil di dalam transaksi yang sama dengan createProduct karena nomor urut SKU dikunci.
+func prepareProduct(tx *gorm.DB, product *models.Product, barcodes *filestore.BarcodeBatch) error {
+	if err := validateNewProduct(*product); err != nil {
+		return err
+	}
+
+	warehouseID, err := resolveWarehouseID(tx, product.WarehouseID)
+	if errors.Is(err, errWarehouseNotFound) {
+		return errInvalidProduct{"Warehouse not found"}
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat barcode"})
-		return
+		return err
 	}
+	product.WarehouseID = warehouseID
 
-	product.BarcodePath = barcodePath
+	if err := assignSKU(tx, product); err != nil {
+		return err
+	}
 
-	if err := database.DB.Create(&product).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to create product"})
-		return
+	// Gambar barcode dirender sekarang agar SKU yang tidak bisa di-encode ditolak, tetapi baru
+	// ditulis ke storage setelah transaksi di-commit
+	key, err := barcodes.Generate(product.SKU)
+	if errors.Is(err, filestore.ErrBarcodeEncode) {
+		return errInvalidProduct{err.Error()}
 	}
+	if err != nil {
+		return err
+	}
+	product.BarcodePath = key
+	return nil
+}
 
-	c.JSON(http.StatusCreated, models.CreateProductResponse{Message: "Product created successfully"})
+// createProduct menyimpan produk baru yang SKU dan barcode-nya sudah disiapkan.
+// Quantity awal dicatat sebagai movement initial di gudang product.WarehouseID (0 = default).
+func createProduct(tx *gorm.DB, product *models.Product, userID uint, reference string) error {
+	// Barcode alternatif hanya dibuat lewat /products/{id}/barcodes yang memvalidasi GTIN-nya;
+	// tanpa Omit gorm akan ikut menyimpan (atau memindahkan) barcode dari body request
+	product.Barcodes = nil
+
+	initial := product.Quantity
+	product.Quantity = 0
+	product.UpdateStatus()
+
+	// Unique index SKU menangkap produk serentak dengan SKU sama yang lolos pemeriksaan skuTaken
+	if err := tx.Omit(clause.Associations).Create(product).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errSKUExists
+		}
+		return err
+	}
+	if initial == 0 {
+		return nil
+	}
+
+	// Stok awal masuk ke gudang tujuan produk
+	err := postWarehouseDelta(tx, product, product.WarehouseID, initial, models.StockMovement{
+		ReasonCode: models.MovementReasonInitial,
+		Reference:  reference,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Omit(clause.Associations).Save(product).Error
 }
 
 // GetProducts godoc
 // @Summary Get all products
-// @Description Get all products
+// @Description Daftar produk dengan paginasi, filter dan pengurutan. Gunakan page/page_size, atau cursor dari next_cursor
+// @Description untuk paginasi keyset yang stabil pada data besar (page diabaikan jika cursor dikirim).
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.ProductSwagger
+// @Param page query int false "Nomor halaman (mulai 1)"
+// @Param page_size query int false "Jumlah per halaman (default 50, maks 200)"
+// @Param cursor query string false "Cursor dari next_cursor halaman sebelumnya"
+// @Param sort query string false "Urutan, pisahkan dengan koma; awalan - untuk menurun (name, sku, quantity, location, status, created_at, updated_at, id)"
+// @Param status query string false "Filter status, pisahkan dengan koma"
+// @Param location query string false "Filter lokasi (teks, sebagian)"
+// @Param location_id query int false "Hanya produk yang memiliki stok di bin ini"
+// @Param warehouse_id query int false "Hanya produk yang memiliki stok di gudang ini"
+// @Param min_quantity query int false "Quantity minimum"
+// @Param max_quantity query int false "Quantity maksimum"
+// @Param created_from query string false "Dibuat sejak (YYYY-MM-DD atau RFC3339)"
+// @Param created_to query string false "Dibuat sampai (inklusif)"
+// @Param updated_from query string false "Diubah sejak"
+// @Param updated_to query string false "Diubah sampai (inklusif)"
+// @Success 200 {object} models.ProductPage
+// @Failure 400 {object} models.ErrorResponse
 // @Router /products [get]
 func GetProducts(c *gin.Context) {
-	var products []models.Product
-	database.DB.Find(&products)
-	c.JSON(http.StatusOK, products)
+	page, pageSize, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	fields, err := parseProductSort(c.Query("sort"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	query, err := applyProductFilters(c, database.DB.Model(&models.Product{}))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to count products"})
+		return
+	}
+
+	cursor := c.Query("cursor")
+	if cursor != "" {
+		if query, err = applyProductCursor(query, fields, cursor); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid cursor"})
+			return
+		}
+		page = 0
+	} else {
+		query = query.Offset((page - 1) * pageSize)
+	}
+
+	for _, field := range fields {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: productSortColumns[field.Field]}, Desc: field.Desc})
+	}
+
+	// Ambil satu baris lebih untuk mengetahui apakah masih ada halaman berikutnya
+	products := []models.Product{}
+	if err := query.Limit(pageSize + 1).Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load products"})
+		return
+	}
+
+	response := models.ProductPage{Page: page, PageSize: pageSize, Total: total}
+	if len(products) > pageSize {
+		products = products[:pageSize]
+		if response.NextCursor, err = encodeProductCursor(fields, products[pageSize-1]); err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to build cursor"})
+			return
+		}
+	}
+	response.Data = products
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetProductByID godoc
@@ -172,29 +319,47 @@ func GetProducts(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path string true "Product ID"
 // @Success 200 {object} models.ProductSwagger
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} models.ErrorResponse
 // @Router /products/{id} [get]
 func GetProductByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
 	var product models.Product
-	id := c.Param("id")
 
-	if err := database.DB.First(&product, id).Error; err != nil {
+	if err := database.DB.Preload("Barcodes").First(&product, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
 		return
 	}
 
+	c.Header("ETag", productETag(product))
 	c.JSON(http.StatusOK, product)
 }
 
 // GetBarcode godoc
 // @Summary Ambil barcode produk
-// @Description Mengembalikan gambar barcode berdasarkan SKU
+// @Description Mengembalikan gambar barcode berdasarkan SKU. Tanpa parameter, PNG yang tersimpan di storage aktif dikirim
+// @Description (dan dibuat ulang jika hilang). Dengan format, symbology, width, height atau dpi, barcode dirender langsung;
+// @Description format=svg menghasilkan gambar vektor yang tetap tajam saat dicetak. width/height dalam pixel,
+// @Description dpi menentukan lebar bar (0,33 mm) dan ukuran fisik SVG. Respons memakai ETag dan Cache-Control.
 // @Tags Products
 // @Produce png
+// @Produce image/svg+xml
 // @Security BearerAuth
 // @Param sku path string true "Product SKU"
+// @Param format query string false "png atau svg (default png)"
+// @Param symbology query string false "code128, code39, ean13, upca, qr atau datamatrix (default BARCODE_SYMBOLOGY)"
+// @Param width query int false "Lebar gambar dalam pixel"
+// @Param height query int false "Tinggi gambar dalam pixel"
+// @Param dpi query int false "Resolusi printer (72-1200)"
 // @Success 200
+// @Success 304
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} models.ErrorResponse
 // @Router /products/barcode/{sku} [get]
 func GetBarcode(c *gin.Context) {
 	sku := c.Param("sku")
@@ -205,7 +370,51 @@ func GetBarcode(c *gin.Context) {
 		return
 	}
 
-	c.File(product.BarcodePath)
+	req, err := parseBarcodeRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if req.isStored() {
+		serveStoredBarcode(c, &product)
+		return
+	}
+
+	// ETag hanya bergantung pada SKU dan parameter, jadi request kondisional dijawab tanpa merender
+	if notModified(c, req.etag(product.SKU)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	data, contentType, err := renderBarcode(product.SKU, req)
+	if err != nil {
+		// Jangan biarkan error ikut di-cache dengan ETag dari notModified
+		c.Header("ETag", "")
+		c.Header("Cache-Control", "")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Cannot render barcode: " + err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// serveStoredBarcode mengirim PNG barcode produk dari storage aktif. File yang hilang dibuat ulang
+// dari SKU; barcode_path lama (misalnya path lokal sebelum storage dipindah) diganti dengan key-nya.
+func serveStoredBarcode(c *gin.Context, product *models.Product) {
+	reader, info, err := openStoredBarcode(c, product.SKU)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load barcode"})
+		return
+	}
+
+	if key := filestore.BarcodeKey(product.SKU); product.BarcodePath != key {
+		if err := database.DB.Model(product).UpdateColumn("barcode_path", key).Error; err != nil {
+			reader.Close()
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load barcode"})
+			return
+		}
+	}
+
+	sendStoredBarcode(c, reader, info)
 }
 
 // ExportProductsCSV godoc
@@ -248,64 +457,240 @@ func ExportProductsCSV(c *gin.Context) {
 
 // GetStockDashboard godoc
 // @Summary Mendapatkan ringkasan stok gudang
-// @Description Mengambil total stok, jumlah produk, dan daftar produk dengan stok rendah
+// @Description Mengambil total stok, jumlah produk, dan daftar produk dengan stok rendah.
+// @Description Tanpa warehouse_id ringkasan diagregasi dari semua gudang beserta rincian per gudang.
 // @Tags Dashboard
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param warehouse_id query int false "Filter per gudang"
 // @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /products/dashboard [get]
 func GetStockDashboard(c *gin.Context) {
 	var totalProducts int64
 	var outOfStock int64
 	var lowStock int64
+	var totalQuantity int64
+
+	if value := c.Query("warehouse_id"); value != "" {
+		warehouseID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse_id"})
+			return
+		}
+
+		var warehouse models.Warehouse
+		if err := database.DB.First(&warehouse, warehouseID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Warehouse not found"})
+			return
+		}
+
+		stocks := func() *gorm.DB {
+			return database.DB.Model(&models.WarehouseStock{}).
+				Joins("JOIN products ON products.id = warehouse_stocks.product_id AND products.deleted_at IS NULL").
+				Where("warehouse_stocks.warehouse_id = ?", warehouse.ID)
+		}
+		stocks().Count(&totalProducts)
+		stocks().Where("warehouse_stocks.quantity <= 0").Count(&outOfStock)
+		stocks().Where("warehouse_stocks.quantity < ?", 10).Count(&lowStock)
+		stocks().Select("COALESCE(SUM(warehouse_stocks.quantity), 0)").Scan(&totalQuantity)
+
+		var latestProducts []models.Product
+		database.DB.Joins("JOIN warehouse_stocks ON warehouse_stocks.product_id = products.id").
+			Where("warehouse_stocks.warehouse_id = ?", warehouse.ID).
+			Order("products.created_at DESC").Limit(5).Find(&latestProducts)
+
+		c.JSON(http.StatusOK, gin.H{
+			"warehouse": warehouse,
+			"summary": gin.H{
+				"totalProducts": totalProducts,
+				"outOfStock":    outOfStock,
+				"lowStock":      lowStock,
+				"totalQuantity": totalQuantity,
+			},
+			"latestProducts": latestProducts,
+		})
+		return
+	}
 
 	database.DB.Model(&models.Product{}).Count(&totalProducts)
-	database.DB.Model(&models.Product{}).Where("quantity = 0").Count(&outOfStock)
+	database.DB.Model(&models.Product{}).Where("quantity <= 0").Count(&outOfStock)
 	database.DB.Model(&models.Product{}).Where("quantity < ?", 10).Count(&lowStock)
+	database.DB.Model(&models.Product{}).Select("COALESCE(SUM(quantity), 0)").Scan(&totalQuantity)
 
 	var latestProducts []models.Product
 	database.DB.Order("created_at DESC").Limit(5).Find(&latestProducts)
 
+	// Rincian per gudang
+	type warehouseSummary struct {
+		WarehouseID   uint   `json:"warehouse_id"`
+		Code          string `json:"code"`
+		Name          string `json:"name"`
+		Products      int64  `json:"products"`
+		TotalQuantity int64  `json:"totalQuantity"`
+	}
+	warehouses := []warehouseSummary{}
+	database.DB.Model(&models.Warehouse{}).
+		Select("warehouses.id AS warehouse_id, warehouses.code, warehouses.name, " +
+			"COUNT(products.id) AS products, COALESCE(SUM(CASE WHEN products.id IS NULL THEN 0 ELSE warehouse_stocks.quantity END), 0) AS total_quantity").
+		Joins("LEFT JOIN warehouse_stocks ON warehouse_stocks.warehouse_id = warehouses.id").
+		Joins("LEFT JOIN products ON products.id = warehouse_stocks.product_id AND products.deleted_at IS NULL").
+		Group("warehouses.id, warehouses.code, warehouses.name").
+		Order("warehouses.code ASC").
+		Scan(&warehouses)
+
 	c.JSON(http.StatusOK, gin.H{
 		"summary": gin.H{
 			"totalProducts": totalProducts,
 			"outOfStock":    outOfStock,
 			"lowStock":      lowStock,
+			"totalQuantity": totalQuantity,
 		},
+		"warehouses":     warehouses,
 		"latestProducts": latestProducts,
 	})
 }
 
 // UpdateProduct godoc
 // @Summary Update a product
-// @Description Update a product by ID
+// @Description Update a product by ID. Changing the SKU regenerates its barcode image and removes the old one. Quantity cannot be changed here; use PUT /products/{id}/stock.
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Product ID"
-// @Param product body models.ProductSwagger true "Product JSON"
+// @Param product body models.ProductUpdate true "Field yang diubah"
+// @Param If-Match header string false "Versi produk yang diharapkan (ETag)"
 // @Success 200 {object} models.CreateProductResponse
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} models.ErrorResponse
 // @Router /products/{id} [put]
 func UpdateProduct(c *gin.Context) {
-	var product models.Product
-	id := c.Param("id")
-
-	if err := database.DB.First(&product, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
+	id, ok := parseIDParam(c, "id")
+	if !ok {
 		return
 	}
 
-	if err := c.ShouldBindJSON(&product); err != nil {
+	var payload models.ProductUpdate
+	if err := c.ShouldBindJSON(&payload); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if payload.Name != nil && strings.TrimSpace(*payload.Name) == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Name is required"})
+		return
+	}
+	if payload.NegativeStockPolicy != nil && *payload.NegativeStockPolicy != "" && !models.IsValidStockPolicy(*payload.NegativeStockPolicy) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid negative stock policy"})
+		return
+	}
+
+	// Versi yang diharapkan bisa dikirim lewat header If-Match atau field "version"
+	expected := payload.Version
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" && ifMatch != "*" {
+		version, err := parseETag(ifMatch)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid If-Match header"})
+			return
+		}
+		expected = &version
+	}
 
-	database.DB.Save(&product)
+	var product models.Product
+	err := barcodeTransaction(c.Request.Context(), func(tx *gorm.DB, barcodes *filestore.BarcodeBatch) error {
+		if err := lockProduct(tx, id, &product); err != nil {
+			return err
+		}
+
+		if expected != nil && *expected != product.Version {
+			return errVersionConflict
+		}
+
+		// Hanya field di ProductUpdate yang disalin; status dihitung ulang, barcode_path mengikuti SKU
+		// dan barcode alternatif diubah lewat /products/{id}/barcodes
+		current := product
+		if payload.Name != nil {
+			product.Name = strings.TrimSpace(*payload.Name)
+		}
+		if payload.SKU != nil {
+			product.SKU = strings.TrimSpace(*payload.SKU)
+		}
+		if payload.Category != nil {
+			product.Category = *payload.Category
+		}
+		if payload.Location != nil {
+			product.Location = *payload.Location
+		}
+		if payload.NegativeStockPolicy != nil {
+			product.NegativeStockPolicy = *payload.NegativeStockPolicy
+		}
+		// Stok hanya berubah lewat /products/{id}/stock agar kebijakan stok negatif dan gudang ikut diterapkan;
+		// quantity yang sama tetap diterima supaya klien yang mengirim record penuh tidak gagal
+		if payload.Quantity != nil && *payload.Quantity != current.Quantity {
+			return errInvalidBody{errors.New("quantity cannot be changed here; use PUT /products/{id}/stock")}
+		}
+		product.Version = current.Version + 1
+
+		// SKU baru divalidasi seperti saat membuat produk dan gambar barcode-nya dibuat ulang
+		if product.SKU != current.SKU {
+			if err := utils.ValidateSKU(product.SKU); err != nil {
+				return errInvalidBody{err}
+			}
+			taken, err := skuTaken(tx, product.SKU)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return errSKUExists
+			}
+			key, err := barcodes.Generate(product.SKU)
+			if errors.Is(err, filestore.ErrBarcodeEncode) {
+				return errInvalidBody{err}
+			}
+			if err != nil {
+				return err
+			}
+			barcodes.Delete(current.SKU)
+			product.BarcodePath = key
+		}
+
+		product.UpdateStatus()
+		err := tx.Save(&product).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errSKUExists
+		}
+		return err
+	})
+
+	var bodyErr errInvalidBody
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
+		return
+	case errors.Is(err, errVersionConflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":           "Product was modified by another request",
+			"current_version": product.Version,
+		})
+		return
+	case errors.As(err, &bodyErr):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: bodyErr.Error()})
+		return
+	case errors.Is(err, errSKUExists):
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "SKU already exists"})
+		return
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update product"})
+		return
+	}
+
+	c.Header("ETag", productETag(product))
 	c.JSON(http.StatusOK, models.CreateProductResponse{Message: "Product updated successfully"})
 }
 
@@ -318,25 +703,27 @@ func UpdateProduct(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path string true "Product ID"
 // @Success 200 {object} models.DeleteProductResponse
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} models.ErrorResponse
 // @Router /products/{id} [delete]
 func DeleteProduct(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
 	var product models.Product
-	id := c.Param("id")
 
 	if err := database.DB.First(&product, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
 		return
 	}
 
+	// Soft delete: gambar barcode tetap disimpan agar produk yang dipulihkan masih memiliki barcode
 	if err := database.DB.Delete(&product).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to delete product"})
 		return
 	}
 
-	if err := utils.DeleteBarcode(product.SKU); err != nil {
-		fmt.Printf("Warning: Failed to delete barcode image for SKU %s: %v\n", product.SKU, err)
-	}
-
 	c.JSON(http.StatusOK, models.DeleteProductResponse{Message: "Product deleted successfully"})
 }
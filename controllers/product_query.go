@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// productSortColumns memetakan nama field pada parameter sort ke kolom tabel products
+var productSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"sku":        "sku",
+	"quantity":   "quantity",
+	"location":   "location",
+	"status":     "status",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// sortField adalah satu kolom pengurutan, misalnya "-quantity" menjadi {quantity, desc}
+type sortField struct {
+	Field string
+	Desc  bool
+}
+
+// productCursor disimpan sebagai base64 JSON berisi sort yang dipakai dan nilai baris terakhir
+type productCursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// parseProductSort membaca sort=-quantity,name. Kolom id selalu ditambahkan di akhir
+// agar urutan stabil dan cursor unik.
+func parseProductSort(value string) ([]sortField, error) {
+	fields := []sortField{}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := sortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if _, ok := productSortColumns[field.Field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q", field.Field)
+		}
+		if seen[field.Field] {
+			continue
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+
+	if !seen["id"] {
+		fields = append(fields, sortField{Field: "id"})
+	}
+	return fields, nil
+}
+
+// sortKey menulis ulang sort dalam bentuk kanonis untuk dicocokkan dengan cursor
+func sortKey(fields []sortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field
+		if field.Desc {
+			parts[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// productSortValue mengambil nilai kolom sort dari sebuah produk
+func productSortValue(product models.Product, field string) interface{} {
+	switch field {
+	case "name":
+		return product.Name
+	case "sku":
+		return product.SKU
+	case "quantity":
+		return product.Quantity
+	case "location":
+		return product.Location
+	case "status":
+		return product.Status
+	case "created_at":
+		return product.CreatedAt
+	case "updated_at":
+		return product.UpdatedAt
+	default:
+		return product.ID
+	}
+}
+
+// decodeSortValue mengubah nilai cursor kembali ke tipe kolomnya
+func decodeSortValue(field string, raw json.RawMessage) (interface{}, error) {
+	switch field {
+	case "quantity":
+		var v int
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "id":
+		var v uint
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "created_at", "updated_at":
+		var v time.Time
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	default:
+		var v string
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	}
+}
+
+// encodeProductCursor membuat cursor halaman berikutnya dari baris terakhir
+func encodeProductCursor(fields []sortField, last models.Product) (string, error) {
+	cursor := productCursor{Sort: sortKey(fields)}
+	for _, field := range fields {
+		raw, err := json.Marshal(productSortValue(last, field.Field))
+		if err != nil {
+			return "", err
+		}
+		cursor.Values = append(cursor.Values, raw)
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// applyProductCursor menambahkan kondisi keyset (a > x) OR (a = x AND b > y) ...
+// sehingga halaman berikutnya dimulai tepat setelah baris terakhir
+func applyProductCursor(query *gorm.DB, fields []sortField, encoded string) (*gorm.DB, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var cursor productCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errInvalidCursor
+	}
+	if cursor.Sort != sortKey(fields) || len(cursor.Values) != len(fields) {
+		return nil, errInvalidCursor
+	}
+
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		if values[i], err = decodeSortValue(field.Field, cursor.Values[i]); err != nil {
+			return nil, errInvalidCursor
+		}
+	}
+
+	clauses := []string{}
+	args := []interface{}{}
+	for i, field := range fields {
+		parts := []string{}
+		for j := 0; j < i; j++ {
+			parts = append(parts, productSortColumns[fields[j].Field]+" = ?")
+			args = append(args, values[j])
+		}
+
+		op := " > ?"
+		if field.Desc {
+			op = " < ?"
+		}
+		parts = append(parts, productSortColumns[field.Field]+op)
+		args = append(args, values[i])
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return query.Where("("+strings.Join(clauses, " OR ")+")", args...), nil
+}
+
+// applyProductFilters menerapkan filter query string GET /products
+func applyProductFilters(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status IN ?", strings.Split(status, ","))
+	}
+
+	if location := c.Query("location"); location != "" {
+		query = query.Where("location LIKE ?", "%"+location+"%")
+	}
+
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := strconv.ParseUint(locationID, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid location_id")
+		}
+		query = query.Where("id IN (?)", database.DB.
+			Model(&models.LocationStock{}).Select("product_id").
+			Where("location_id = ? AND quantity <> 0", id))
+	}
+
+	if warehouseID := c.Query("warehouse_id"); warehouseID != "" {
+		id, err := strconv.ParseUint(warehouseID, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid warehouse_id")
+		}
+		query = query.Where("id IN (?)", database.DB.
+			Model(&models.WarehouseStock{}).Select("product_id").
+			Where("warehouse_id = ? AND quantity <> 0", id))
+	}
+
+	if value := c.Query("min_quantity"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("invalid min_quantity")
+		}
+		query = query.Where("quantity >= ?", n)
+	}
+
+	if value := c.Query("max_quantity"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("invalid max_quantity")
+		}
+		query = query.Where("quantity <= ?", n)
+	}
+
+	for _, column := range []string{"created", "updated"} {
+		if value := c.Query(column + "_from"); value != "" {
+			t, _, err := parseDateParam(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s_from date", column)
+			}
+			query = query.Where(column+"_at >= ?", t)
+		}
+
+		if value := c.Query(column + "_to"); value != "" {
+			t, dateOnly, err := parseDateParam(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s_to date", column)
+			}
+			// Tanggal tanpa jam mencakup seluruh hari tersebut
+			if dateOnly {
+				query = query.Where(column+"_at < ?", t.AddDate(0, 0, 1))
+			} else {
+				query = query.Where(column+"_at <= ?", t)
+			}
+		}
+	}
+
+	return query, nil
+}
+
+// parsePageParams membaca page dan page_size dengan batas maxPageSize
+func parsePageParams(c *gin.Context) (int, int, error) {
+	page, pageSize := 1, defaultPageSize
+
+	if value := c.Query("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return 0, 0, errors.New("invalid page")
+		}
+		page = n
+	}
+
+	if value := c.Query("page_size"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return 0, 0, errors.New("invalid page_size")
+		}
+		pageSize = n
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize, nil
+}
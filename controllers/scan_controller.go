@@ -0,0 +1,350 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxScanImageSize membatasi ukuran file foto yang didekode
+const maxScanImageSize = 10 << 20
+
+var (
+	errScanEmpty    = errors.New("scanned code is empty")
+	errScanNotFound = errors.New("no product or location matches the scanned code")
+	// Scan-and-adjust hanya berlaku untuk produk
+	errScanNotProduct = errors.New("scanned code is not a product")
+)
+
+// scanResolver mencoba mencocokkan kode dengan satu jenis data; (nil, nil) berarti tidak cocok
+type scanResolver func(tx *gorm.DB, code string) (*models.ScanResult, error)
+
+// scanResolvers dicoba berurutan; kecocokan pertama yang dipakai sehingga produk
+// selalu didahulukan daripada lokasi
+var scanResolvers = []scanResolver{
+	scanBySKU,
+	scanByProductBarcode,
+	scanByGTIN,
+	scanByLocationCode,
+}
+
+// scanBySKU mencocokkan SKU persis, lalu tanpa membedakan huruf besar (scanner Code 39 hanya mengirim huruf besar)
+func scanBySKU(tx *gorm.DB, code string) (*models.ScanResult, error) {
+	var product models.Product
+	err := tx.Where("sku = ?", code).First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = tx.Where("LOWER(sku) = LOWER(?)", code).First(&product).Error
+	}
+	return productScan(product, models.ScanMatchSKU, err)
+}
+
+// scanByProductBarcode mencocokkan barcode alternatif (barcode pabrik atau karton). GTIN dicocokkan
+// dalam bentuk GTIN-14 sehingga UPC-A yang terbaca sebagai EAN-13 tetap ditemukan.
+func scanByProductBarcode(tx *gorm.DB, code string) (*models.ScanResult, error) {
+	query := tx.Where("code = ?", code)
+	if variants := utils.GTINVariants(code); len(variants) > 0 {
+		query = tx.Where("code = ? OR gtin = ?", code, utils.GTIN14(variants[0]))
+	}
+
+	var barcode models.ProductBarcode
+	if err := query.First(&barcode).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var product models.Product
+	err := tx.First(&product, barcode.ProductID).Error
+	result, err := productScan(product, models.ScanMatchBarcode, err)
+	if result != nil {
+		result.Barcode = &barcode
+		result.PackQuantity = barcode.PackQuantity
+	}
+	return result, err
+}
+
+// scanByGTIN mencocokkan SKU berupa GTIN dalam bentuk lain, misalnya UPC-A yang dibaca sebagai EAN-13
+func scanByGTIN(tx *gorm.DB, code string) (*models.ScanResult, error) {
+	variants := utils.GTINVariants(code)
+	if len(variants) == 0 {
+		return nil, nil
+	}
+
+	var product models.Product
+	err := tx.Where("sku IN ?", variants).Order("id").First(&product).Error
+	return productScan(product, models.ScanMatchGTIN, err)
+}
+
+// scanByLocationCode mencocokkan kode lokasi (bin), yang selalu disimpan dalam huruf besar
+func scanByLocationCode(tx *gorm.DB, code string) (*models.ScanResult, error) {
+	var location models.Location
+	err := tx.Preload("Warehouse").Where("code = ?", strings.ToUpper(code)).First(&location).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.ScanResult{Type: models.ScanTypeLocation, MatchedBy: models.ScanMatchLocationCode, Location: &location}, nil
+}
+
+// productScan membungkus hasil query produk menjadi ScanResult
+func productScan(product models.Product, matchedBy string, err error) (*models.ScanResult, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.ScanResult{Type: models.ScanTypeProduct, MatchedBy: matchedBy, PackQuantity: 1, Product: &product}, nil
+}
+
+// resolveScan membersihkan kode hasil scan lalu mencari produk atau lokasi yang cocok
+func resolveScan(tx *gorm.DB, raw string) (models.ScanResult, error) {
+	code := utils.NormalizeScan(raw)
+	if code == "" {
+		return models.ScanResult{}, errScanEmpty
+	}
+
+	for _, resolve := range scanResolvers {
+		result, err := resolve(tx, code)
+		if err != nil {
+			return models.ScanResult{}, err
+		}
+		if result != nil {
+			result.Code = code
+			return *result, nil
+		}
+	}
+	return models.ScanResult{Code: code}, errScanNotFound
+}
+
+// loadScanDetails melengkapi hasil scan dengan stok per gudang (produk) atau isi bin (lokasi)
+func loadScanDetails(tx *gorm.DB, result *models.ScanResult) error {
+	if result.Product != nil {
+		if err := tx.Where("product_id = ?", result.Product.ID).Find(&result.Product.Barcodes).Error; err != nil {
+			return err
+		}
+		result.Stock = []models.WarehouseStock{}
+		return tx.Preload("Warehouse").Where("product_id = ?", result.Product.ID).Order("warehouse_id").Find(&result.Stock).Error
+	}
+	result.Contents = []models.LocationStock{}
+	return tx.Preload("Product").
+		Where("location_id = ? AND quantity <> 0", result.Location.ID).
+		Order("product_id").Find(&result.Contents).Error
+}
+
+// respondScanError memetakan error resolveScan ke respons HTTP
+func respondScanError(c *gin.Context, code string, err error) {
+	switch {
+	case errors.Is(err, errScanEmpty):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Scanned code is required"})
+	case errors.Is(err, errScanNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "No product or location matches the scanned code", "code": code})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to look up scanned code"})
+	}
+}
+
+// ScanCode godoc
+// @Summary Cari produk atau lokasi dari hasil scan
+// @Description Mencocokkan kode mentah dari scanner dengan SKU produk (persis lalu tanpa beda huruf besar), barcode alternatif
+// @Description produk, GTIN setara (UPC-A 12 digit, EAN-13 dan GTIN-14 termasuk awalan GS1 01) dan kode lokasi. Awalan AIM
+// @Description (]C1, ]E0, ...) serta CR/LF dari scanner dibuang. Produk dikembalikan beserta stok per gudang dan pack_quantity
+// @Description (jumlah unit per scan, misalnya isi karton), lokasi beserta isinya.
+// @Tags Scan
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Kode hasil scan"
+// @Success 200 {object} models.ScanResult
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /scan/{code} [get]
+func ScanCode(c *gin.Context) {
+	code := c.Param("code")
+
+	result, err := resolveScan(database.DB, code)
+	if err != nil {
+		respondScanError(c, result.Code, err)
+		return
+	}
+	if err := loadScanDetails(database.DB, &result); err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load stock"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ScanAndAdjust godoc
+// @Summary Scan lalu sesuaikan stok
+// @Description Mencari produk dari kode hasil scan seperti GET /scan/{code} lalu langsung mengubah stoknya sebesar
+// @Description change x pack_quantity dalam satu transaksi. Lokasi bisa dikirim sebagai location_id atau location_code hasil scan bin.
+// @Description Reference default "scan:<kode>". Kebijakan stok negatif produk tetap berlaku.
+// @Tags Scan
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Kode hasil scan"
+// @Param body body models.ScanAdjustRequest true "Perubahan stok"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 422 {object} map[string]interface{}
+// @Router /scan/{code} [post]
+func ScanAndAdjust(c *gin.Context) {
+	code := c.Param("code")
+
+	var request models.ScanAdjustRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+	if request.Change == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "change must not be zero"})
+		return
+	}
+	if request.Reason == "" {
+		request.Reason = models.MovementReasonAdjustment
+	}
+	if !models.IsValidMovementReason(request.Reason) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid reason code"})
+		return
+	}
+
+	var scan models.ScanResult
+	var result stockResult
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		scan, err = resolveScan(tx, code)
+		if err != nil {
+			return err
+		}
+		if scan.Type != models.ScanTypeProduct {
+			return errScanNotProduct
+		}
+
+		locationID := request.LocationID
+		if request.LocationCode != "" {
+			bin, err := scanByLocationCode(tx, utils.NormalizeScan(request.LocationCode))
+			if err != nil {
+				return err
+			}
+			if bin == nil {
+				return errLocationNotFound
+			}
+			locationID = bin.Location.ID
+		}
+
+		reference := request.Reference
+		if reference == "" {
+			reference = "scan:" + scan.Code
+			if len(reference) > 100 {
+				reference = reference[:100]
+			}
+		}
+
+		// Scan barcode karton menghitung seluruh isi kemasan
+		result, err = adjustStock(tx, stockChange{
+			ProductID:   scan.Product.ID,
+			WarehouseID: request.WarehouseID,
+			LocationID:  locationID,
+			Change:      request.Change * scan.PackQuantity,
+			Reason:      request.Reason,
+			Reference:   reference,
+			UserID:      middleware.CurrentUserID(c),
+		})
+		return err
+	})
+	switch {
+	case errors.Is(err, errScanEmpty), errors.Is(err, errScanNotFound):
+		respondScanError(c, scan.Code, err)
+		return
+	case errors.Is(err, errScanNotProduct):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Scanned code is a location, not a product", "scan": scan})
+		return
+	case err != nil:
+		respondStockError(c, err)
+		return
+	}
+
+	scan.Product = &result.Product
+	response := stockResponse(result)
+	response["scan"] = scan
+	c.Header("ETag", productETag(result.Product))
+	c.JSON(http.StatusOK, response)
+}
+
+// ScanImage godoc
+// @Summary Dekode barcode dari gambar
+// @Description Membaca foto atau screenshot label (JPEG/PNG, maks 10 MB, field "file") lalu mendekode Code 128, Code 39,
+// @Description EAN/UPC, ITF, QR dan Data Matrix di dalamnya. Setiap kode dicocokkan seperti GET /scan/{code}; match null
+// @Description jika kode tidak dikenal. 422 jika tidak ada barcode yang terbaca.
+// @Tags Scan
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Gambar JPEG atau PNG"
+// @Success 200 {object} models.ImageScanResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 422 {object} models.ErrorResponse
+// @Router /scan/image [post]
+func ScanImage(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Image file is required in field 'file'"})
+		return
+	}
+	if header.Size > maxScanImageSize {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Image file is larger than 10 MB"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Failed to read image file"})
+		return
+	}
+	defer file.Close()
+
+	decoded, err := utils.ReadBarcodeImage(file)
+	switch {
+	case errors.Is(err, utils.ErrImageFormat), errors.Is(err, utils.ErrImageTooLarge):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to decode image"})
+		return
+	case len(decoded) == 0:
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{Error: "No barcode found in image"})
+		return
+	}
+
+	response := models.ImageScanResponse{Barcodes: []models.ImageScanResult{}}
+	for _, barcode := range decoded {
+		item := models.ImageScanResult{Symbology: barcode.Symbology, Code: barcode.Text}
+		result, err := resolveScan(database.DB, barcode.Text)
+		switch {
+		case err == nil:
+			if err := loadScanDetails(database.DB, &result); err != nil {
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load stock"})
+				return
+			}
+			item.Match = &result
+		case !errors.Is(err, errScanNotFound) && !errors.Is(err, errScanEmpty):
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to look up scanned code"})
+			return
+		}
+		response.Barcodes = append(response.Barcodes, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
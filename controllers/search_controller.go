@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"html"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// Jumlah kandidat maksimum dari database sebelum diberi skor
+	searchCandidateLimit = 500
+)
+
+// Bobot field saat menghitung relevansi; SKU paling spesifik
+var searchFieldWeights = []struct {
+	Name   string
+	Weight float64
+}{
+	{"sku", 3},
+	{"name", 2},
+	{"location", 1},
+}
+
+// searchTerms memecah query menjadi kata unik berhuruf kecil
+func searchTerms(q string) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, word := range utils.SplitWords(q) {
+		if !seen[word.Text] {
+			seen[word.Text] = true
+			terms = append(terms, word.Text)
+		}
+	}
+	return terms
+}
+
+// searchCandidates mengambil produk yang mungkin cocok. MySQL memakai FULLTEXT (ditambah LIKE
+// pada SKU karena FULLTEXT memecah SKU di tanda hubung), driver lain memakai LIKE.
+func searchCandidates(terms []string) ([]models.Product, error) {
+	candidates := []models.Product{}
+
+	if database.SupportsFullText(database.DB) {
+		match := database.DB.Where("MATCH("+database.ProductSearchColumns+") AGAINST (? IN BOOLEAN MODE)", strings.Join(terms, "* ")+"*")
+		for _, term := range terms {
+			match = match.Or("sku LIKE ?", "%"+term+"%")
+		}
+		err := database.DB.Where(match).Limit(searchCandidateLimit).Find(&candidates).Error
+		if err == nil {
+			return candidates, nil
+		}
+		// Index belum dibuat (migrasi belum dijalankan): lanjut dengan LIKE
+	}
+
+	query := database.DB.Model(&models.Product{})
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		query = query.Where("(name LIKE ? OR sku LIKE ? OR location LIKE ?)", pattern, pattern, pattern)
+	}
+	err := query.Limit(searchCandidateLimit).Find(&candidates).Error
+	return candidates, err
+}
+
+// fuzzyCandidates mengambil kandidat untuk input yang mungkin salah ketik berdasarkan
+// dua karakter awal setiap kata; pencocokan sebenarnya dilakukan saat pemberian skor
+func fuzzyCandidates(terms []string, exclude []uint) ([]models.Product, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	for _, term := range terms {
+		if utils.MaxTypos(term) == 0 {
+			continue
+		}
+		prefix := string([]rune(term)[:2])
+		for _, column := range []string{"name", "sku", "location"} {
+			conditions = append(conditions, column+" LIKE ?", column+" LIKE ?", column+" LIKE ?")
+			args = append(args, prefix+"%", "% "+prefix+"%", "%-"+prefix+"%")
+		}
+	}
+
+	query := database.DB.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	if len(exclude) > 0 {
+		query = query.Where("id NOT IN ?", exclude)
+	}
+
+	candidates := []models.Product{}
+	err := query.Limit(searchCandidateLimit).Find(&candidates).Error
+	return candidates, err
+}
+
+// matchField mencocokkan semua term dengan satu field. Mengembalikan skor per term
+// (0 jika tidak cocok) dan rentang rune yang perlu di-highlight. Urutan skor:
+// kata sama persis 6, awalan kata 4, potongan di tengah kata 2, salah ketik 1-1.5.
+func matchField(value string, terms []string) ([]float64, [][2]int) {
+	scores := make([]float64, len(terms))
+	ranges := [][2]int{}
+	words := utils.SplitWords(value)
+	lower := []rune(strings.ToLower(value))
+
+	for i, term := range terms {
+		n := len([]rune(term))
+		best := 0.0
+		var matched [][2]int
+		add := func(score float64, r [2]int) {
+			if score > best {
+				best, matched = score, nil
+			}
+			if score == best {
+				matched = append(matched, r)
+			}
+		}
+
+		for _, word := range words {
+			switch {
+			case word.Text == term:
+				add(6, [2]int{word.Start, word.Start + n})
+			case strings.HasPrefix(word.Text, term):
+				add(4, [2]int{word.Start, word.Start + n})
+			default:
+				if distance, ok := utils.FuzzyPrefix(term, word.Text); ok {
+					add(2-0.5*float64(distance), [2]int{word.Start, word.Start + min(n, len([]rune(word.Text)))})
+				}
+			}
+		}
+
+		// Potongan di tengah kata, misalnya bagian angka SKU
+		if best < 2 {
+			if idx := strings.Index(string(lower), term); idx >= 0 {
+				start := len([]rune(string(lower)[:idx]))
+				add(2, [2]int{start, start + n})
+			}
+		}
+
+		scores[i] = best
+		ranges = append(ranges, matched...)
+	}
+
+	return scores, ranges
+}
+
+// highlight membungkus rentang rune yang cocok dengan <em></em>. Teks di-escape sebagai HTML
+// karena nama, SKU dan lokasi berasal dari input user dan hasilnya biasanya dirender sebagai HTML.
+func highlight(value string, ranges [][2]int) string {
+	runes := []rune(value)
+	marked := make([]bool, len(runes))
+	for _, r := range ranges {
+		for i := r[0]; i < r[1] && i < len(runes); i++ {
+			marked[i] = true
+		}
+	}
+
+	var b strings.Builder
+	start := 0
+	for i := range runes {
+		// Tutup segmen saat status marked berubah atau di akhir teks
+		if i < len(runes)-1 && marked[i] == marked[i+1] {
+			continue
+		}
+		segment := html.EscapeString(string(runes[start : i+1]))
+		if marked[i] {
+			segment = "<em>" + segment + "</em>"
+		}
+		b.WriteString(segment)
+		start = i + 1
+	}
+	return b.String()
+}
+
+// scoreProduct menghitung relevansi produk. Produk ditolak jika ada term yang tidak cocok di field mana pun.
+func scoreProduct(product models.Product, q string, terms []string) (models.ProductSearchResult, bool) {
+	values := map[string]string{"sku": product.SKU, "name": product.Name, "location": product.Location}
+	result := models.ProductSearchResult{Product: product, Highlights: map[string]string{}}
+	best := make([]float64, len(terms))
+
+	for _, field := range searchFieldWeights {
+		value := values[field.Name]
+		scores, ranges := matchField(value, terms)
+		for i, score := range scores {
+			best[i] = max(best[i], score*field.Weight)
+		}
+		if len(ranges) > 0 {
+			result.Highlights[field.Name] = highlight(value, ranges)
+		}
+		// Hasil scan yang sama persis dengan field (biasanya SKU) selalu di urutan teratas
+		if strings.EqualFold(strings.TrimSpace(q), value) {
+			result.Score += 100 * field.Weight
+		}
+	}
+
+	for _, score := range best {
+		if score == 0 {
+			return result, false
+		}
+		result.Score += score
+	}
+	return result, true
+}
+
+// SearchProducts godoc
+// @Summary Cari produk
+// @Description Mencari produk berdasarkan sebagian nama, potongan SKU atau lokasi. Hasil diurutkan menurut relevansi
+// @Description (SKU > nama > lokasi) dan bagian yang cocok ditandai dengan <em></em>. Input dari scanner yang salah
+// @Description satu-dua karakter tetap ditemukan lewat pencocokan awalan dengan toleransi salah ketik.
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Kata kunci"
+// @Param limit query int false "Jumlah hasil maksimum (default 20, maks 100)"
+// @Success 200 {object} models.ProductSearchResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Router /products/search [get]
+func SearchProducts(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	terms := searchTerms(q)
+	if len(terms) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Query parameter 'q' is required"})
+		return
+	}
+
+	limit := defaultSearchLimit
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid limit"})
+			return
+		}
+		limit = min(n, maxSearchLimit)
+	}
+
+	candidates, err := searchCandidates(terms)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to search products"})
+		return
+	}
+
+	results := []models.ProductSearchResult{}
+	seen := []uint{}
+	for _, product := range candidates {
+		seen = append(seen, product.ID)
+		if result, ok := scoreProduct(product, q, terms); ok {
+			results = append(results, result)
+		}
+	}
+
+	// Hasil kurang dari limit: coba lagi dengan toleransi salah ketik
+	if len(results) < limit && hasFuzzyTerm(terms) {
+		more, err := fuzzyCandidates(terms, seen)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to search products"})
+			return
+		}
+		for _, product := range more {
+			if result, ok := scoreProduct(product, q, terms); ok {
+				results = append(results, result)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Product.ID < results[j].Product.ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	c.JSON(http.StatusOK, models.ProductSearchResponse{Query: q, Total: len(results), Data: results})
+}
+
+// hasFuzzyTerm reports whether at least one term is long enough for typo tolerance
+func hasFuzzyTerm(terms []string) bool {
+	for _, term := range terms {
+		if utils.MaxTypos(term) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"gorm.io/gorm"
+)
+
+// errSKUExists dikembalikan jika SKU yang diisi user sudah dipakai produk lain (termasuk yang sudah dihapus)
+var errSKUExists = errors.New("SKU already exists")
+
+// skuGenerator membuat SKU baru untuk produk di dalam transaksi pembuatan produk
+type skuGenerator interface {
+	Generate(tx *gorm.DB, product models.Product) (string, error)
+}
+
+// sequenceSKUGenerator merender SKU_PATTERN dengan nomor urut dari tabel sku_sequences
+type sequenceSKUGenerator struct {
+	pattern string
+}
+
+func (g sequenceSKUGenerator) Generate(tx *gorm.DB, product models.Product) (string, error) {
+	if err := utils.ValidateSKUPattern(g.pattern); err != nil {
+		return "", err
+	}
+
+	// {WH} mengikuti gudang tujuan produk, atau gudang default jika tidak diisi
+	warehouse, err := findWarehouse(tx, product.WarehouseID)
+	if err != nil {
+		return "", err
+	}
+	fields := utils.SKUFields{Category: product.Category, Warehouse: warehouse.Code, Time: time.Now()}
+
+	seq, err := database.NextSequence(tx, utils.SKUSequenceKey(g.pattern, fields))
+	if err != nil {
+		return "", err
+	}
+	return utils.RenderSKU(g.pattern, fields, seq), nil
+}
+
+// timestampSKUGenerator adalah format lama SKU-<milidetik>
+type timestampSKUGenerator struct{}
+
+func (timestampSKUGenerator) Generate(tx *gorm.DB, product models.Product) (string, error) {
+	return utils.GenerateSKU(), nil
+}
+
+// currentSKUGenerator memilih generator dari SKU_GENERATOR (sequence atau timestamp)
+func currentSKUGenerator() skuGenerator {
+	if utils.GetEnv("SKU_GENERATOR", "sequence") == "timestamp" {
+		return timestampSKUGenerator{}
+	}
+	return sequenceSKUGenerator{pattern: utils.SKUPattern()}
+}
+
+// skuTaken memeriksa apakah SKU sudah dipakai, termasuk oleh produk yang sudah dihapus,
+// sebagai barcode alternatif produk lain atau sebagai kode lokasi. Kode lokasi dibandingkan
+// tanpa membedakan huruf besar karena scan SKU juga begitu, dan gambar barcode keduanya
+// disimpan di key yang sama (filestore.BarcodeKey).
+func skuTaken(tx *gorm.DB, sku string) (bool, error) {
+	var count int64
+	err := tx.Unscoped().Model(&models.Product{}).Where("sku = ?", sku).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = tx.Unscoped().Model(&models.Location{}).Where("code = ?", strings.ToUpper(sku)).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	query := tx.Model(&models.ProductBarcode{}).Where("code = ?", sku)
+	if utils.ValidGTIN(sku) {
+		query = tx.Model(&models.ProductBarcode{}).Where("code = ? OR gtin = ?", sku, utils.GTIN14(sku))
+	}
+	err = query.Count(&count).Error
+	return count > 0, err
+}
+
+// assignSKU memvalidasi SKU yang diisi user atau membuat SKU baru yang dijamin belum dipakai
+func assignSKU(tx *gorm.DB, product *models.Product) error {
+	product.SKU = strings.TrimSpace(product.SKU)
+	if product.SKU != "" {
+		if err := utils.ValidateSKU(product.SKU); err != nil {
+			return errInvalidProduct{err.Error()}
+		}
+		taken, err := skuTaken(tx, product.SKU)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errSKUExists
+		}
+		return nil
+	}
+
+	// SKU hasil generator bisa bentrok dengan SKU lama atau SKU yang diisi manual; coba nomor berikutnya
+	generator := currentSKUGenerator()
+	for attempt := 0; attempt < 10; attempt++ {
+		sku, err := generator.Generate(tx, *product)
+		if err != nil {
+			return err
+		}
+		taken, err := skuTaken(tx, sku)
+		if err != nil {
+			return err
+		}
+		if !taken {
+			product.SKU = sku
+			return nil
+		}
+		if _, ok := generator.(timestampSKUGenerator); ok {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	return errors.New("could not generate a unique SKU")
+}
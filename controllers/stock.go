@@ -0,0 +1,368 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Hasil penerapan kebijakan stok negatif
+const (
+	StockOutcomeApplied     = "applied"
+	StockOutcomeClamped     = "clamped"
+	StockOutcomeBackordered = "backordered"
+)
+
+// StockAdjustment describes how a requested stock change was applied
+type StockAdjustment struct {
+	Policy          string `json:"policy" example:"reject"`
+	Outcome         string `json:"outcome" example:"applied"`
+	RequestedChange int    `json:"requested_change" example:"-5"`
+	AppliedChange   int    `json:"applied_change" example:"-5"`
+}
+
+// InsufficientStockError dikembalikan saat kebijakan reject menolak pengurangan stok
+type InsufficientStockError struct {
+	ProductID uint
+	Available int
+	Requested int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock: available %d, requested %d", e.Available, e.Requested)
+}
+
+// respond menulis respons 422 yang terstruktur
+func (e *InsufficientStockError) respond(c *gin.Context) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"error":      "Insufficient stock",
+		"code":       "insufficient_stock",
+		"product_id": e.ProductID,
+		"available":  e.Available,
+		"requested":  e.Requested,
+		"policy":     models.StockPolicyReject,
+	})
+}
+
+// globalStockPolicy membaca NEGATIVE_STOCK_POLICY, default reject
+func globalStockPolicy() string {
+	policy := utils.GetEnv("NEGATIVE_STOCK_POLICY", models.StockPolicyReject)
+	if !models.IsValidStockPolicy(policy) {
+		return models.StockPolicyReject
+	}
+	return policy
+}
+
+// effectiveStockPolicy memilih kebijakan produk jika ada, jika tidak memakai setting global
+func effectiveStockPolicy(product models.Product) string {
+	if models.IsValidStockPolicy(product.NegativeStockPolicy) {
+		return product.NegativeStockPolicy
+	}
+	return globalStockPolicy()
+}
+
+// applyStockPolicy menghitung perubahan yang benar-benar diterapkan pada stok yang tersedia
+func applyStockPolicy(available, change int, policy string) (StockAdjustment, error) {
+	adjustment := StockAdjustment{
+		Policy:          policy,
+		Outcome:         StockOutcomeApplied,
+		RequestedChange: change,
+		AppliedChange:   change,
+	}
+
+	result := available + change
+	if change >= 0 || result >= 0 {
+		return adjustment, nil
+	}
+
+	switch policy {
+	case models.StockPolicyAllow:
+		adjustment.Outcome = StockOutcomeBackordered
+	case models.StockPolicyClamp:
+		adjustment.Outcome = StockOutcomeClamped
+		adjustment.AppliedChange = -available
+		if available < 0 {
+			adjustment.AppliedChange = 0
+		}
+	default:
+		return adjustment, &InsufficientStockError{Available: available, Requested: -change}
+	}
+	return adjustment, nil
+}
+
+var (
+	// errWarehouseNotFound dikembalikan jika gudang tujuan penyesuaian tidak ada
+	errWarehouseNotFound = errors.New("warehouse not found")
+	// errLocationNotFound dikembalikan jika lokasi (bin) tidak ada
+	errLocationNotFound = errors.New("location not found")
+	// errLocationMismatch dikembalikan jika lokasi bukan milik gudang yang diminta
+	errLocationMismatch = errors.New("location does not belong to warehouse")
+)
+
+// stockChange is a single stock adjustment to be applied inside a transaction
+type stockChange struct {
+	ProductID   uint
+	WarehouseID uint // 0 berarti gudang default
+	LocationID  uint // 0 berarti stok gudang yang belum ditempatkan di bin
+	Change      int
+	Reason      string
+	Reference   string
+	UserID      uint
+	TransferID  *uint
+	Policy      string // kosong berarti kebijakan produk / global
+}
+
+// stockResult is the state after a stockChange has been applied
+type stockResult struct {
+	Product    models.Product
+	Stock      models.WarehouseStock
+	Bin        *models.LocationStock
+	Adjustment StockAdjustment
+}
+
+// lockProduct membaca produk dengan SELECT ... FOR UPDATE agar perubahan stok serentak tidak saling menimpa
+func lockProduct(tx *gorm.DB, id uint, product *models.Product) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(product, id).Error
+}
+
+// defaultWarehouse mengambil gudang default, dibuat otomatis jika belum ada
+func defaultWarehouse(tx *gorm.DB) (models.Warehouse, error) {
+	warehouse := models.Warehouse{Code: models.DefaultWarehouseCode}
+	err := tx.Where("code = ?", models.DefaultWarehouseCode).
+		Attrs(models.Warehouse{Name: "Gudang Utama"}).
+		FirstOrCreate(&warehouse).Error
+	return warehouse, err
+}
+
+// findWarehouse mengambil gudang berdasarkan ID, 0 berarti gudang default. Gudang dibaca
+// dengan FOR SHARE agar tidak bisa dihapus sampai transaksi pemanggil selesai.
+func findWarehouse(tx *gorm.DB, warehouseID uint) (models.Warehouse, error) {
+	if warehouseID == 0 {
+		return defaultWarehouse(tx)
+	}
+
+	var warehouse models.Warehouse
+	if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).First(&warehouse, warehouseID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return warehouse, errWarehouseNotFound
+		}
+		return warehouse, err
+	}
+	return warehouse, nil
+}
+
+// resolveWarehouseID memastikan gudang ada dan mengganti 0 dengan gudang default
+func resolveWarehouseID(tx *gorm.DB, warehouseID uint) (uint, error) {
+	warehouse, err := findWarehouse(tx, warehouseID)
+	return warehouse.ID, err
+}
+
+// lockWarehouseStock mengunci baris stok produk di gudang, dibuat jika belum ada.
+// Pemanggil harus sudah mengunci baris produk agar pembuatan baris tidak bentrok.
+func lockWarehouseStock(tx *gorm.DB, productID, warehouseID uint) (models.WarehouseStock, error) {
+	stock := models.WarehouseStock{ProductID: productID, WarehouseID: warehouseID}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
+		FirstOrCreate(&stock).Error
+	return stock, err
+}
+
+// lockLocationStock mengunci baris stok produk di sebuah lokasi, dibuat jika belum ada
+func lockLocationStock(tx *gorm.DB, productID, locationID uint) (models.LocationStock, error) {
+	stock := models.LocationStock{ProductID: productID, LocationID: locationID}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND location_id = ?", productID, locationID).
+		FirstOrCreate(&stock).Error
+	return stock, err
+}
+
+// findLocation mengambil lokasi dan memastikan cocok dengan gudang yang diminta (jika ada)
+func findLocation(tx *gorm.DB, locationID, warehouseID uint) (models.Location, error) {
+	var location models.Location
+	if err := tx.First(&location, locationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return location, errLocationNotFound
+		}
+		return location, err
+	}
+	if warehouseID != 0 && warehouseID != location.WarehouseID {
+		return location, errLocationMismatch
+	}
+	return location, nil
+}
+
+// postStockDelta applies delta to the bin (optional), the warehouse stock row and
+// the product total and appends the ledger entry. The caller is responsible for
+// saving product.
+func postStockDelta(tx *gorm.DB, product *models.Product, stock *models.WarehouseStock, bin *models.LocationStock, delta int, movement models.StockMovement) error {
+	if delta == 0 {
+		return nil
+	}
+
+	if bin != nil {
+		bin.Quantity += delta
+		if err := tx.Model(bin).Update("quantity", bin.Quantity).Error; err != nil {
+			return err
+		}
+		locationID := bin.LocationID
+		movement.LocationID = &locationID
+	}
+
+	stock.Quantity += delta
+	if err := tx.Model(stock).Update("quantity", stock.Quantity).Error; err != nil {
+		return err
+	}
+
+	product.Quantity += delta
+	product.UpdateStatus()
+
+	warehouseID := stock.WarehouseID
+	movement.ProductID = product.ID
+	movement.WarehouseID = &warehouseID
+	movement.Delta = delta
+	movement.QuantityAfter = product.Quantity
+	return tx.Create(&movement).Error
+}
+
+// postWarehouseDelta posts delta against a warehouse (0 = default) without
+// applying the negative stock policy, e.g. for initial stock.
+func postWarehouseDelta(tx *gorm.DB, product *models.Product, warehouseID uint, delta int, movement models.StockMovement) error {
+	if delta == 0 {
+		return nil
+	}
+
+	warehouseID, err := resolveWarehouseID(tx, warehouseID)
+	if err != nil {
+		return err
+	}
+	stock, err := lockWarehouseStock(tx, product.ID, warehouseID)
+	if err != nil {
+		return err
+	}
+	return postStockDelta(tx, product, &stock, nil, delta, movement)
+}
+
+// adjustStock locks the product and its warehouse stock, applies the negative
+// stock policy and records the movement.
+func adjustStock(tx *gorm.DB, change stockChange) (stockResult, error) {
+	var result stockResult
+
+	if err := lockProduct(tx, change.ProductID, &result.Product); err != nil {
+		return result, err
+	}
+
+	var warehouseID uint
+	var location models.Location
+	var err error
+	if change.LocationID != 0 {
+		location, err = findLocation(tx, change.LocationID, change.WarehouseID)
+		warehouseID = location.WarehouseID
+	} else {
+		warehouseID, err = resolveWarehouseID(tx, change.WarehouseID)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.Stock, err = lockWarehouseStock(tx, result.Product.ID, warehouseID)
+	if err != nil {
+		return result, err
+	}
+
+	// Stok yang tersedia dihitung di level paling spesifik: bin jika disebut, jika tidak gudang
+	available := result.Stock.Quantity
+	if change.LocationID != 0 {
+		bin, err := lockLocationStock(tx, result.Product.ID, location.ID)
+		if err != nil {
+			return result, err
+		}
+		result.Bin = &bin
+		available = bin.Quantity
+	}
+
+	policy := change.Policy
+	if policy == "" {
+		policy = effectiveStockPolicy(result.Product)
+	}
+	result.Adjustment, err = applyStockPolicy(available, change.Change, policy)
+	if err != nil {
+		var stockErr *InsufficientStockError
+		if errors.As(err, &stockErr) {
+			stockErr.ProductID = result.Product.ID
+		}
+		return result, err
+	}
+
+	err = postStockDelta(tx, &result.Product, &result.Stock, result.Bin, result.Adjustment.AppliedChange, models.StockMovement{
+		ReasonCode: change.Reason,
+		Reference:  change.Reference,
+		UserID:     change.UserID,
+		TransferID: change.TransferID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Product.Version++
+	return result, tx.Save(&result.Product).Error
+}
+
+// respondStockError memetakan error dari adjustStock ke respons HTTP
+func respondStockError(c *gin.Context, err error) {
+	var stockErr *InsufficientStockError
+	switch {
+	case errors.As(err, &stockErr):
+		stockErr.respond(c)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+	case errors.Is(err, errWarehouseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Warehouse not found"})
+	case errors.Is(err, errLocationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Location not found"})
+	case errors.Is(err, errLocationMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Location does not belong to the warehouse"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update stock"})
+	}
+}
+
+// bindStockRequest membaca dan memvalidasi body StockUpdateRequest
+func bindStockRequest(c *gin.Context) (StockUpdateRequest, bool) {
+	var request StockUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return request, false
+	}
+
+	if request.Reason == "" {
+		request.Reason = models.MovementReasonAdjustment
+	}
+	if !models.IsValidMovementReason(request.Reason) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reason code"})
+		return request, false
+	}
+	return request, true
+}
+
+// stockResponse membentuk body respons setelah penyesuaian stok
+func stockResponse(result stockResult) gin.H {
+	response := gin.H{
+		"message":            "Stock updated",
+		"quantity":           result.Product.Quantity,
+		"warehouse_id":       result.Stock.WarehouseID,
+		"warehouse_quantity": result.Stock.Quantity,
+		"version":            result.Product.Version,
+		"adjustment":         result.Adjustment,
+	}
+	if result.Bin != nil {
+		response["location_id"] = result.Bin.LocationID
+		response["location_quantity"] = result.Bin.Quantity
+	}
+	return response
+}
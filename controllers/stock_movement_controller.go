@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+// GetProductMovements godoc
+// @Summary Riwayat pergerakan stok produk
+// @Description Mengambil ledger pergerakan stok sebuah produk, dapat difilter berdasarkan rentang tanggal (YYYY-MM-DD atau RFC3339)
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param from query string false "Tanggal awal (inklusif)"
+// @Param to query string false "Tanggal akhir (inklusif)"
+// @Param warehouse_id query int false "Filter per gudang"
+// @Success 200 {array} models.StockMovement
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /products/{id}/movements [get]
+func GetProductMovements(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var product models.Product
+	if err := database.DB.Unscoped().First(&product, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Database error"})
+		return
+	}
+
+	query := database.DB.Where("product_id = ?", product.ID)
+
+	if value := c.Query("warehouse_id"); value != "" {
+		warehouseID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid warehouse_id"})
+			return
+		}
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, _, err := parseDateParam(from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid 'from' date"})
+			return
+		}
+		query = query.Where("created_at >= ?", t)
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, dateOnly, err := parseDateParam(to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid 'to' date"})
+			return
+		}
+		// Tanggal tanpa jam mencakup seluruh hari tersebut
+		if dateOnly {
+			query = query.Where("created_at < ?", t.AddDate(0, 0, 1))
+		} else {
+			query = query.Where("created_at <= ?", t)
+		}
+	}
+
+	movements := []models.StockMovement{}
+	if err := query.Order("created_at ASC, id ASC").Find(&movements).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to load movements"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movements)
+}
+
+// parseDateParam menerima format YYYY-MM-DD atau RFC3339
+func parseDateParam(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(dateLayout, value, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errInvalidRefreshToken = errors.New("invalid refresh token")
+	errRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+// issueTokenPair membuat access token dan refresh token baru. familyID kosong berarti sesi login baru.
+func issueTokenPair(tx *gorm.DB, user models.User, familyID string) (models.TokenResponse, *models.RefreshToken, error) {
+	var response models.TokenResponse
+
+	if familyID == "" {
+		var err error
+		if familyID, err = utils.RandomToken(16); err != nil {
+			return response, nil, err
+		}
+	}
+
+	rawRefresh, err := utils.RandomToken(32)
+	if err != nil {
+		return response, nil, err
+	}
+
+	refresh := models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(rawRefresh),
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL()),
+	}
+	if err := tx.Create(&refresh).Error; err != nil {
+		return response, nil, err
+	}
+
+	access, err := utils.GenerateToken(user.ID, user.Role)
+	if err != nil {
+		return response, nil, err
+	}
+
+	response = models.TokenResponse{
+		Token:        access,
+		RefreshToken: rawRefresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(utils.AccessTokenTTL().Seconds()),
+	}
+	return response, &refresh, nil
+}
+
+// rotateRefreshToken menukar refresh token lama dengan pasangan token baru.
+// Jika token yang sudah pernah dipakai dikirim lagi, seluruh family dicabut.
+func rotateRefreshToken(tx *gorm.DB, raw string) (models.TokenResponse, error) {
+	var response models.TokenResponse
+
+	var current models.RefreshToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", utils.HashToken(raw)).
+		First(&current).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response, errInvalidRefreshToken
+		}
+		return response, err
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil {
+		if err := revokeRefreshFamily(tx, current.FamilyID, now); err != nil {
+			return response, err
+		}
+		return response, errRefreshTokenReused
+	}
+	if now.After(current.ExpiresAt) {
+		return response, errInvalidRefreshToken
+	}
+
+	var user models.User
+	if err := tx.First(&user, current.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response, errInvalidRefreshToken
+		}
+		return response, err
+	}
+	if !user.IsActive() {
+		return response, errInvalidRefreshToken
+	}
+
+	response, next, err := issueTokenPair(tx, user, current.FamilyID)
+	if err != nil {
+		return response, err
+	}
+
+	err = tx.Model(&current).Updates(models.RefreshToken{RevokedAt: &now, ReplacedByID: &next.ID}).Error
+	return response, err
+}
+
+// revokeRefreshFamily mencabut semua refresh token yang masih aktif dalam satu family
+func revokeRefreshFamily(tx *gorm.DB, familyID string, now time.Time) error {
+	return tx.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", now).Error
+}
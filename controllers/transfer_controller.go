@@ -0,0 +1,370 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errTransferStatus dikembalikan jika aksi tidak sesuai status transfer saat ini
+var errTransferStatus = errors.New("invalid transfer status")
+
+// CreateTransfer godoc
+// @Summary Buat dokumen transfer stok
+// @Description Membuat transfer berstatus draft dari gudang/lokasi asal ke gudang/lokasi tujuan. Stok belum berubah sampai transfer di-dispatch.
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param transfer body models.TransferSwagger true "Transfer JSON"
+// @Success 201 {object} models.Transfer
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /transfers [post]
+func CreateTransfer(c *gin.Context) {
+	var payload models.TransferSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if len(payload.Lines) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Transfer must have at least one line"})
+		return
+	}
+
+	// Gabungkan baris dengan produk yang sama
+	quantities := map[uint]int{}
+	for _, line := range payload.Lines {
+		if line.Quantity <= 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Line quantity must be greater than zero"})
+			return
+		}
+		quantities[line.ProductID] += line.Quantity
+	}
+
+	transfer := models.Transfer{
+		SourceWarehouseID:      payload.SourceWarehouseID,
+		SourceLocationID:       payload.SourceLocationID,
+		DestinationWarehouseID: payload.DestinationWarehouseID,
+		DestinationLocationID:  payload.DestinationLocationID,
+		Status:                 models.TransferStatusDraft,
+		Note:                   payload.Note,
+		CreatedBy:              middleware.CurrentUserID(c),
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := validateTransferEndpoint(tx, transfer.SourceWarehouseID, transfer.SourceLocationID); err != nil {
+			return err
+		}
+		if err := validateTransferEndpoint(tx, transfer.DestinationWarehouseID, transfer.DestinationLocationID); err != nil {
+			return err
+		}
+		if sameTransferEndpoint(transfer) {
+			return errInvalidBody{errors.New("source and destination must differ")}
+		}
+
+		productIDs := make([]uint, 0, len(quantities))
+		for productID := range quantities {
+			productIDs = append(productIDs, productID)
+		}
+		sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+		var found int64
+		if err := tx.Model(&models.Product{}).Where("id IN ?", productIDs).Count(&found).Error; err != nil {
+			return err
+		}
+		if int(found) != len(productIDs) {
+			return gorm.ErrRecordNotFound
+		}
+
+		for _, productID := range productIDs {
+			transfer.Lines = append(transfer.Lines, models.TransferLine{
+				ProductID: productID,
+				Quantity:  quantities[productID],
+			})
+		}
+
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+		transfer.Number = fmt.Sprintf("TRF-%06d", transfer.ID)
+		return tx.Model(&transfer).Update("number", transfer.Number).Error
+	})
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// GetTransfers godoc
+// @Summary Daftar transfer
+// @Tags Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter status (draft, in_transit, received)"
+// @Param warehouse_id query int false "Transfer yang berasal dari atau menuju gudang ini"
+// @Success 200 {array} models.Transfer
+// @Failure 400 {object} models.ErrorResponse
+// @Router /transfers [get]
+func GetTransfers(c *gin.Context) {
+	query := database.DB.Preload("Lines")
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if value := c.Query("warehouse_id"); value != "" {
+		warehouseID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid warehouse_id"})
+			return
+		}
+		query = query.Where("source_warehouse_id = ? OR destination_warehouse_id = ?", warehouseID, warehouseID)
+	}
+
+	transfers := []models.Transfer{}
+	query.Order("created_at DESC").Find(&transfers)
+	c.JSON(http.StatusOK, transfers)
+}
+
+// GetTransferByID godoc
+// @Summary Ambil transfer berdasarkan ID
+// @Tags Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.Transfer
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /transfers/{id} [get]
+func GetTransferByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var transfer models.Transfer
+	if err := database.DB.Preload("Lines.Product").First(&transfer, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Transfer not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// GetTransferMovements godoc
+// @Summary Pergerakan stok milik transfer
+// @Description Mengambil movement transfer_out dan transfer_in yang ditulis oleh transfer ini
+// @Tags Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {array} models.StockMovement
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /transfers/{id}/movements [get]
+func GetTransferMovements(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var transfer models.Transfer
+	if err := database.DB.Unscoped().First(&transfer, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Transfer not found"})
+		return
+	}
+
+	movements := []models.StockMovement{}
+	database.DB.Where("transfer_id = ?", transfer.ID).Order("created_at ASC, id ASC").Find(&movements)
+	c.JSON(http.StatusOK, movements)
+}
+
+// DispatchTransfer godoc
+// @Summary Kirim transfer
+// @Description Mengurangi stok di asal untuk setiap baris dan mengubah status menjadi in_transit. Stok harus mencukupi.
+// @Tags Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.Transfer
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 422 {object} map[string]interface{}
+// @Router /transfers/{id}/dispatch [post]
+func DispatchTransfer(c *gin.Context) {
+	postTransfer(c, models.TransferStatusDraft, models.TransferStatusInTransit)
+}
+
+// ReceiveTransfer godoc
+// @Summary Terima transfer
+// @Description Menambah stok di tujuan untuk setiap baris dan mengubah status menjadi received
+// @Tags Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.Transfer
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /transfers/{id}/receive [post]
+func ReceiveTransfer(c *gin.Context) {
+	postTransfer(c, models.TransferStatusInTransit, models.TransferStatusReceived)
+}
+
+// DeleteTransfer godoc
+// @Summary Hapus transfer draft
+// @Tags Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.DeleteProductResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /transfers/{id} [delete]
+func DeleteTransfer(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var transfer models.Transfer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, id).Error; err != nil {
+			return err
+		}
+		if transfer.Status != models.TransferStatusDraft {
+			return errTransferStatus
+		}
+		return tx.Delete(&transfer).Error
+	})
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeleteProductResponse{Message: "Transfer deleted successfully"})
+}
+
+// postTransfer menjalankan dispatch atau receive secara atomik untuk semua baris
+func postTransfer(c *gin.Context, from, to string) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID := middleware.CurrentUserID(c)
+
+	var transfer models.Transfer
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("product_id ASC") }).
+			First(&transfer, id).Error; err != nil {
+			return err
+		}
+		if transfer.Status != from {
+			return errTransferStatus
+		}
+
+		for _, line := range transfer.Lines {
+			change := stockChange{
+				ProductID:  line.ProductID,
+				Reference:  transfer.Number,
+				UserID:     userID,
+				TransferID: &transfer.ID,
+				// Barang fisik harus ada untuk dikirim, jadi transfer selalu memakai kebijakan reject
+				Policy: models.StockPolicyReject,
+			}
+			if to == models.TransferStatusInTransit {
+				change.WarehouseID = transfer.SourceWarehouseID
+				change.LocationID = derefUint(transfer.SourceLocationID)
+				change.Change = -line.Quantity
+				change.Reason = models.MovementReasonTransferOut
+			} else {
+				change.WarehouseID = transfer.DestinationWarehouseID
+				change.LocationID = derefUint(transfer.DestinationLocationID)
+				change.Change = line.Quantity
+				change.Reason = models.MovementReasonTransferIn
+			}
+
+			if _, err := adjustStock(tx, change); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{"status": to}
+		if to == models.TransferStatusInTransit {
+			updates["dispatched_by"] = userID
+			updates["dispatched_at"] = now
+		} else {
+			updates["received_by"] = userID
+			updates["received_at"] = now
+		}
+		return tx.Model(&transfer).Updates(updates).Error
+	})
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	database.DB.Preload("Lines").First(&transfer, transfer.ID)
+	c.JSON(http.StatusOK, transfer)
+}
+
+// validateTransferEndpoint memastikan gudang ada dan lokasi (jika ada) milik gudang tersebut
+func validateTransferEndpoint(tx *gorm.DB, warehouseID uint, locationID *uint) error {
+	if warehouseID == 0 {
+		return errInvalidBody{errors.New("source and destination warehouse are required")}
+	}
+	if _, err := resolveWarehouseID(tx, warehouseID); err != nil {
+		return err
+	}
+	if locationID != nil {
+		if _, err := findLocation(tx, *locationID, warehouseID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameTransferEndpoint bernilai true jika asal dan tujuan adalah tempat yang sama
+func sameTransferEndpoint(transfer models.Transfer) bool {
+	return transfer.SourceWarehouseID == transfer.DestinationWarehouseID &&
+		derefUint(transfer.SourceLocationID) == derefUint(transfer.DestinationLocationID)
+}
+
+func derefUint(value *uint) uint {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// respondTransferError memetakan error transfer ke respons HTTP
+func respondTransferError(c *gin.Context, err error) {
+	var bodyErr errInvalidBody
+	switch {
+	case errors.As(err, &bodyErr):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: bodyErr.Error()})
+	case errors.Is(err, errTransferStatus):
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Transfer status does not allow this action"})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Transfer or product not found"})
+	default:
+		respondStockError(c, err)
+	}
+}
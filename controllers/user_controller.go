@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// errLastAdmin dikembalikan jika perubahan akan menghilangkan admin aktif terakhir
+	errLastAdmin = errors.New("cannot remove the last admin")
+	errSelfUser  = errors.New("cannot disable or delete yourself")
+)
+
+// ensureOtherAdmin memastikan masih ada admin aktif selain user yang akan diubah. Baris admin lain
+// dikunci (SELECT ... FOR UPDATE) agar dua demosi atau penghapusan serentak tidak sama-sama lolos.
+func ensureOtherAdmin(tx *gorm.DB, user models.User) error {
+	if user.Role != models.RoleAdmin || !user.IsActive() {
+		return nil
+	}
+
+	var admins []uint
+	if err := tx.Model(&models.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ? AND disabled_at IS NULL AND id <> ?", models.RoleAdmin, user.ID).
+		Order("id").Pluck("id", &admins).Error; err != nil {
+		return err
+	}
+	if len(admins) == 0 {
+		return errLastAdmin
+	}
+	return nil
+}
+
+// revokeUserSessions mencabut semua refresh token milik user
+func revokeUserSessions(tx *gorm.DB, userID uint, now time.Time) error {
+	return tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+}
+
+// respondUserError memetakan error perubahan user ke response HTTP
+func respondUserError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case errors.Is(err, errLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove the last admin"})
+	case errors.Is(err, errSelfUser):
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot disable or delete your own account"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// GetUsers godoc
+// @Summary Daftar user
+// @Description Mengambil semua user beserta role-nya (khusus admin)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.User
+// @Failure 403 {object} map[string]string
+// @Router /users [get]
+func GetUsers(c *gin.Context) {
+	users := []models.User{}
+	database.DB.Order("id ASC").Find(&users)
+	c.JSON(http.StatusOK, users)
+}
+
+// UpdateUserRole godoc
+// @Summary Ubah role user
+// @Description Menetapkan role admin, manager, operator atau viewer (khusus admin). Role baru langsung berlaku.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param body body models.RoleUpdate true "Role baru"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/{id}/role [put]
+func UpdateUserRole(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var payload models.RoleUpdate
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !models.IsValidRole(payload.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+
+	var user models.User
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			return err
+		}
+
+		if payload.Role != models.RoleAdmin {
+			if err := ensureOtherAdmin(tx, user); err != nil {
+				return err
+			}
+		}
+
+		user.Role = payload.Role
+		return tx.Model(&user).Update("role", user.Role).Error
+	})
+	if err != nil {
+		respondUserError(c, err, "Failed to update role")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateUserStatus godoc
+// @Summary Aktifkan / nonaktifkan user
+// @Description Menonaktifkan user (active=false) langsung menolak token yang masih berlaku dan mencabut semua sesinya (khusus admin)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param body body models.UserStatusUpdate true "Status baru"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/{id}/status [put]
+func UpdateUserStatus(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var payload models.UserStatusUpdate
+	if err := c.ShouldBindJSON(&payload); err != nil || payload.Active == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "active is required"})
+		return
+	}
+
+	var user models.User
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			return err
+		}
+
+		if *payload.Active {
+			user.DisabledAt = nil
+			return tx.Model(&user).Update("disabled_at", nil).Error
+		}
+
+		if user.ID == middleware.CurrentUserID(c) {
+			return errSelfUser
+		}
+		if err := ensureOtherAdmin(tx, user); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		user.DisabledAt = &now
+		if err := tx.Model(&user).Update("disabled_at", now).Error; err != nil {
+			return err
+		}
+		return revokeUserSessions(tx, user.ID, now)
+	})
+	if err != nil {
+		respondUserError(c, err, "Failed to update user status")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser godoc
+// @Summary Hapus user
+// @Description Menghapus user (soft delete) dan mencabut semua sesinya. Token yang masih berlaku langsung ditolak (khusus admin).
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/{id} [delete]
+func DeleteUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			return err
+		}
+		if user.ID == middleware.CurrentUserID(c) {
+			return errSelfUser
+		}
+		if err := ensureOtherAdmin(tx, user); err != nil {
+			return err
+		}
+
+		if err := revokeUserSessions(tx, user.ID, time.Now()); err != nil {
+			return err
+		}
+		return tx.Delete(&user).Error
+	})
+	if err != nil {
+		respondUserError(c, err, "Failed to delete user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
+}
@@ -0,0 +1,354 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// errDefaultWarehouse dikembalikan saat gudang default akan dihapus
+	errDefaultWarehouse = errors.New("default warehouse cannot be deleted")
+	// errWarehouseStocked dikembalikan saat gudang yang akan dihapus masih berisi stok
+	errWarehouseStocked = errors.New("warehouse still holds stock")
+	// errWarehouseHasLocations dikembalikan saat gudang yang akan dihapus masih memiliki lokasi
+	errWarehouseHasLocations = errors.New("warehouse still has locations")
+)
+
+// CreateWarehouse godoc
+// @Summary Tambah gudang
+// @Description Membuat gudang baru dengan kode unik
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param warehouse body models.WarehouseSwagger true "Warehouse JSON"
+// @Success 201 {object} models.Warehouse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /warehouses [post]
+func CreateWarehouse(c *gin.Context) {
+	var payload models.WarehouseSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	warehouse := models.Warehouse{
+		Code:    payload.Code,
+		Name:    payload.Name,
+		Address: payload.Address,
+	}
+	if err := warehouse.Normalize(); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var count int64
+	database.DB.Unscoped().Model(&models.Warehouse{}).Where("code = ?", warehouse.Code).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Warehouse code already exists"})
+		return
+	}
+
+	if err := database.DB.Create(&warehouse).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to create warehouse"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, warehouse)
+}
+
+// GetWarehouses godoc
+// @Summary Daftar gudang
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Warehouse
+// @Router /warehouses [get]
+func GetWarehouses(c *gin.Context) {
+	warehouses := []models.Warehouse{}
+	database.DB.Order("code ASC").Find(&warehouses)
+	c.JSON(http.StatusOK, warehouses)
+}
+
+// GetWarehouseByID godoc
+// @Summary Ambil gudang berdasarkan ID
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} models.Warehouse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /warehouses/{id} [get]
+func GetWarehouseByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var warehouse models.Warehouse
+	if err := database.DB.First(&warehouse, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Warehouse not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+// UpdateWarehouse godoc
+// @Summary Update gudang
+// @Description Mengubah nama dan alamat gudang. Kode gudang tidak dapat diubah.
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Param warehouse body models.WarehouseSwagger true "Warehouse JSON"
+// @Success 200 {object} models.Warehouse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /warehouses/{id} [put]
+func UpdateWarehouse(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var warehouse models.Warehouse
+	if err := database.DB.First(&warehouse, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Warehouse not found"})
+		return
+	}
+
+	var payload models.WarehouseSwagger
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if name := strings.TrimSpace(payload.Name); name != "" {
+		warehouse.Name = name
+	}
+	warehouse.Address = payload.Address
+
+	if err := database.DB.Save(&warehouse).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update warehouse"})
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+// DeleteWarehouse godoc
+// @Summary Hapus gudang
+// @Description Menghapus gudang yang sudah tidak memiliki stok maupun lokasi. Gudang default tidak dapat dihapus.
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} models.DeleteProductResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /warehouses/{id} [delete]
+func DeleteWarehouse(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Gudang dikunci FOR UPDATE agar penyesuaian stok dan lokasi baru yang membaca gudang
+	// (FOR SHARE) menunggu sampai pemeriksaan dan penghapusan selesai
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var warehouse models.Warehouse
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&warehouse, id).Error; err != nil {
+			return err
+		}
+		if warehouse.Code == models.DefaultWarehouseCode {
+			return errDefaultWarehouse
+		}
+
+		var stocked int64
+		err := tx.Model(&models.WarehouseStock{}).
+			Where("warehouse_id = ? AND quantity <> 0", warehouse.ID).
+			Count(&stocked).Error
+		if err != nil {
+			return err
+		}
+		if stocked > 0 {
+			return errWarehouseStocked
+		}
+
+		var locations int64
+		if err := tx.Model(&models.Location{}).Where("warehouse_id = ?", warehouse.ID).Count(&locations).Error; err != nil {
+			return err
+		}
+		if locations > 0 {
+			return errWarehouseHasLocations
+		}
+
+		return tx.Delete(&warehouse).Error
+	})
+
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Warehouse not found"})
+		return
+	case errors.Is(err, errDefaultWarehouse):
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Default warehouse cannot be deleted"})
+		return
+	case errors.Is(err, errWarehouseStocked):
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Warehouse still holds stock"})
+		return
+	case errors.Is(err, errWarehouseHasLocations):
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Warehouse still has locations"})
+		return
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to delete warehouse"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeleteProductResponse{Message: "Warehouse deleted successfully"})
+}
+
+// GetWarehouseStock godoc
+// @Summary Stok per gudang
+// @Description Mengambil stok semua produk pada sebuah gudang
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Success 200 {array} models.WarehouseStock
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /warehouses/{id}/stock [get]
+func GetWarehouseStock(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var warehouse models.Warehouse
+	if err := database.DB.First(&warehouse, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Warehouse not found"})
+		return
+	}
+
+	stocks := []models.WarehouseStock{}
+	database.DB.Preload("Product").
+		Joins("JOIN products ON products.id = warehouse_stocks.product_id AND products.deleted_at IS NULL").
+		Where("warehouse_stocks.warehouse_id = ?", warehouse.ID).
+		Order("products.name ASC").
+		Find(&stocks)
+
+	c.JSON(http.StatusOK, stocks)
+}
+
+// UpdateWarehouseStock godoc
+// @Summary Ubah stok produk di gudang tertentu
+// @Description Menambah atau mengurangi stok produk pada gudang dan mencatatnya di ledger
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Param product_id path int true "Product ID"
+// @Param body body StockUpdateRequest true "Stock change request (warehouse_id diabaikan)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Router /warehouses/{id}/stock/{product_id} [put]
+func UpdateWarehouseStock(c *gin.Context) {
+	warehouseID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	productID, ok := parseIDParam(c, "product_id")
+	if !ok {
+		return
+	}
+
+	request, ok := bindStockRequest(c)
+	if !ok {
+		return
+	}
+
+	var result stockResult
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = adjustStock(tx, stockChange{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			LocationID:  request.LocationID,
+			Change:      request.Change,
+			Reason:      request.Reason,
+			Reference:   request.Reference,
+			UserID:      middleware.CurrentUserID(c),
+		})
+		return err
+	})
+	if err != nil {
+		respondStockError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stockResponse(result))
+}
+
+// GetProductStock godoc
+// @Summary Rincian stok produk per gudang dan per lokasi
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} models.ErrorResponse
+// @Router /products/{id}/stock [get]
+func GetProductStock(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var product models.Product
+	if err := database.DB.First(&product, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Product not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Database error"})
+		return
+	}
+
+	stocks := []models.WarehouseStock{}
+	database.DB.Preload("Warehouse").
+		Where("product_id = ?", product.ID).
+		Order("warehouse_id ASC").
+		Find(&stocks)
+
+	locations := []models.LocationStock{}
+	database.DB.Preload("Location").
+		Joins("JOIN locations ON locations.id = location_stocks.location_id").
+		Where("location_stocks.product_id = ? AND location_stocks.quantity <> 0", product.ID).
+		Order("locations.code ASC").
+		Find(&locations)
+
+	c.JSON(http.StatusOK, gin.H{
+		"product_id": product.ID,
+		"quantity":   product.Quantity,
+		"warehouses": stocks,
+		"locations":  locations,
+	})
+}
@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"warehouse-backend/database"
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+	"warehouse-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Batas salinan dan jumlah print job yang dikembalikan
+const (
+	maxZPLCopies        = 1000
+	defaultPrintJobList = 100
+)
+
+// errNoPrinter dikembalikan jika ZPL_PRINTER belum diatur
+var errNoPrinter = errors.New("no printer configured, set ZPL_PRINTER")
+
+// buildZPLJob memvalidasi request, membuat ZPL untuk produk dan mengisi print job (belum disimpan)
+func buildZPLJob(c *gin.Context) (models.PrintJob, error) {
+	var req models.ZPLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return models.PrintJob{}, errLabelRequest{http.StatusBadRequest, err.Error()}
+	}
+
+	if req.Template == "" {
+		req.Template = utils.DefaultZPLTemplate
+	}
+	template, err := labelTemplate(req.Template)
+	if err != nil {
+		return models.PrintJob{}, err
+	}
+	if template.PerPage() != 1 {
+		return models.PrintJob{}, errLabelRequest{http.StatusBadRequest, "ZPL needs a single-label (thermal) template"}
+	}
+
+	job := models.PrintJob{
+		Template:  template.Name,
+		Symbology: strings.ToLower(req.Symbology),
+		DPI:       req.DPI,
+		Copies:    req.Copies,
+		UserID:    middleware.CurrentUserID(c),
+	}
+	if job.Symbology == "" {
+		job.Symbology = utils.DefaultSymbology()
+	}
+	if !utils.IsValidSymbology(job.Symbology) {
+		return job, errLabelRequest{http.StatusBadRequest, fmt.Sprintf("Unsupported symbology %q", req.Symbology)}
+	}
+	if job.DPI == 0 {
+		job.DPI = utils.ZPLDPI()
+	}
+	if !utils.ValidZPLDPI(job.DPI) {
+		return job, errLabelRequest{http.StatusBadRequest, "dpi must be 152, 203, 300 or 600"}
+	}
+	if job.Copies == 0 {
+		job.Copies = 1
+	}
+	if job.Copies < 1 || job.Copies > maxZPLCopies {
+		return job, errLabelRequest{http.StatusBadRequest, fmt.Sprintf("copies must be between 1 and %d", maxZPLCopies)}
+	}
+
+	product, err := findLabelProduct(database.DB, req.ProductID, req.SKU)
+	if err != nil {
+		return job, err
+	}
+	job.ProductID = product.ID
+	job.SKU = product.SKU
+
+	label := utils.LabelContent{Name: product.Name, SKU: product.SKU, Location: product.Location}
+	job.ZPL, err = utils.ZPLLabel(template, label, utils.ZPLOptions{Symbology: job.Symbology, DPI: job.DPI, Copies: job.Copies})
+	if err != nil {
+		return job, errLabelRequest{http.StatusBadRequest, "Cannot render label: " + err.Error()}
+	}
+	return job, nil
+}
+
+// sendPrintJob mengirim ZPL job ke printer yang dikonfigurasi lalu menyimpan hasilnya.
+// Job tetap disimpan jika printer gagal agar kegagalan bisa dilacak dan dicetak ulang.
+func sendPrintJob(job *models.PrintJob) error {
+	job.Printer = utils.ZPLPrinter()
+	if job.Printer == "" {
+		return errNoPrinter
+	}
+
+	sendErr := utils.SendToPrinter(job.Printer, []byte(job.ZPL))
+	job.Status = models.PrintJobSent
+	if sendErr != nil {
+		job.Status = models.PrintJobFailed
+		job.Error = sendErr.Error()
+		if len(job.Error) > 255 {
+			job.Error = job.Error[:255]
+		}
+	}
+	if err := database.DB.Create(job).Error; err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// respondPrintJob menulis hasil sendPrintJob: 201 jika terkirim, 502 beserta job jika printer gagal
+func respondPrintJob(c *gin.Context, job models.PrintJob, err error) {
+	switch {
+	case err == nil:
+		c.JSON(http.StatusCreated, job)
+	case errors.Is(err, errNoPrinter):
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{Error: "No printer configured, set ZPL_PRINTER"})
+	case job.Status == models.PrintJobFailed:
+		c.JSON(http.StatusBadGateway, job)
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save print job"})
+	}
+}
+
+// GenerateZPL godoc
+// @Summary Label ZPL untuk printer Zebra
+// @Description Membuat label ZPL II untuk satu produk (product_id atau sku) dengan perintah barcode bawaan printer
+// @Description (^BC untuk Code 128) berisi nama, barcode, SKU dan lokasi. Hanya template thermal (satu label per halaman).
+// @Description dpi default ZPL_DPI (203). Setiap ZPL yang dibuat dicatat sebagai print job berstatus generated,
+// @Description ID-nya dikirim di header X-Print-Job-ID.
+// @Tags Labels
+// @Accept json
+// @Produce plain
+// @Security BearerAuth
+// @Param request body models.ZPLRequest true "Produk dan template label"
+// @Success 200 {string} string "ZPL"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /labels/zpl [post]
+func GenerateZPL(c *gin.Context) {
+	job, err := buildZPLJob(c)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	job.Status = models.PrintJobGenerated
+	if err := database.DB.Create(&job).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save print job"})
+		return
+	}
+
+	c.Header("X-Print-Job-ID", strconv.FormatUint(uint64(job.ID), 10))
+	c.Header("Content-Disposition", "attachment; filename=label-"+job.SKU+".zpl")
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(job.ZPL))
+}
+
+// PrintZPL godoc
+// @Summary Cetak label ke printer Zebra
+// @Description Membuat label ZPL seperti /labels/zpl lalu mengirimnya ke printer ZPL_PRINTER lewat TCP (port 9100).
+// @Description Hasilnya dicatat sebagai print job; jika printer gagal, job berstatus failed dikembalikan dengan 502.
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ZPLRequest true "Produk dan template label"
+// @Success 201 {object} models.PrintJob
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 502 {object} models.PrintJob
+// @Failure 503 {object} models.ErrorResponse
+// @Router /labels/print [post]
+func PrintZPL(c *gin.Context) {
+	job, err := buildZPLJob(c)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	err = sendPrintJob(&job)
+	respondPrintJob(c, job, err)
+}
+
+// GetPrintJobs godoc
+// @Summary Daftar print job
+// @Description Print job terbaru lebih dulu, bisa difilter dengan product_id dan status
+// @Tags Labels
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query int false "Product ID"
+// @Param status query string false "generated, sent atau failed"
+// @Param limit query int false "Jumlah maksimum (default 100)"
+// @Success 200 {array} models.PrintJob
+// @Router /labels/jobs [get]
+func GetPrintJobs(c *gin.Context) {
+	query := database.DB.Model(&models.PrintJob{})
+	if productID := c.Query("product_id"); productID != "" {
+		query = query.Where("product_id = ?", productID)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	limit := defaultPrintJobList
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		limit = min(n, 1000)
+	}
+
+	jobs := []models.PrintJob{}
+	query.Order("created_at DESC").Order("id DESC").Limit(limit).Find(&jobs)
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetPrintJobByID godoc
+// @Summary Ambil print job
+// @Tags Labels
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Print job ID"
+// @Success 200 {object} models.PrintJob
+// @Failure 404 {object} models.ErrorResponse
+// @Router /labels/jobs/{id} [get]
+func GetPrintJobByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var job models.PrintJob
+	if err := database.DB.First(&job, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Print job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// ReprintJob godoc
+// @Summary Cetak ulang print job
+// @Description Mengirim ulang ZPL yang tersimpan (label sama persis dengan cetakan asli) ke printer ZPL_PRINTER.
+// @Description Cetak ulang dicatat sebagai print job baru dengan reprint_of_id menunjuk ke job asal.
+// @Tags Labels
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Print job ID"
+// @Success 201 {object} models.PrintJob
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 502 {object} models.PrintJob
+// @Failure 503 {object} models.ErrorResponse
+// @Router /labels/jobs/{id}/reprint [post]
+func ReprintJob(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var original models.PrintJob
+	if err := database.DB.First(&original, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Print job not found"})
+		return
+	}
+
+	job := models.PrintJob{
+		ProductID:   original.ProductID,
+		SKU:         original.SKU,
+		Template:    original.Template,
+		Symbology:   original.Symbology,
+		DPI:         original.DPI,
+		Copies:      original.Copies,
+		ZPL:         original.ZPL,
+		ReprintOfID: &original.ID,
+		UserID:      middleware.CurrentUserID(c),
+	}
+	err := sendPrintJob(&job)
+	respondPrintJob(c, job, err)
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+const (
+	// ProductSearchIndex adalah nama index FULLTEXT untuk pencarian produk (khusus MySQL)
+	ProductSearchIndex = "ft_products_search"
+	// ProductSearchColumns harus sama persis dengan kolom index agar MATCH dapat memakainya
+	ProductSearchColumns = "name, sku, location"
+)
+
+// SupportsFullText reports whether the connected database can serve MATCH ... AGAINST queries
+func SupportsFullText(db *gorm.DB) bool {
+	return db.Dialector.Name() == "mysql"
+}
+
+// EnsureProductSearchIndex membuat index FULLTEXT pada products jika belum ada.
+// Driver selain MySQL dilewati; pencarian akan memakai LIKE.
+func EnsureProductSearchIndex(db *gorm.DB) error {
+	if !SupportsFullText(db) {
+		return nil
+	}
+
+	var count int64
+	err := db.Raw(
+		"SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?",
+		"products", ProductSearchIndex,
+	).Scan(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return db.Exec("ALTER TABLE products ADD FULLTEXT INDEX " + ProductSearchIndex + " (" + ProductSearchColumns + ")").Error
+}
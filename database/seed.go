@@ -0,0 +1,66 @@
+package database
+
+import (
+	"errors"
+	"warehouse-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// SeedDefaultWarehouse membuat gudang default dan memindahkan stok produk lama
+// (sebelum multi-gudang) ke gudang tersebut.
+func SeedDefaultWarehouse(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		warehouse := models.Warehouse{Code: models.DefaultWarehouseCode}
+		err := tx.Where("code = ?", models.DefaultWarehouseCode).
+			Attrs(models.Warehouse{Name: "Gudang Utama"}).
+			FirstOrCreate(&warehouse).Error
+		if err != nil {
+			return err
+		}
+
+		// Produk dengan stok yang belum punya baris per gudang
+		var products []models.Product
+		err = tx.Unscoped().
+			Where("quantity <> 0").
+			Where("NOT EXISTS (SELECT 1 FROM warehouse_stocks WHERE warehouse_stocks.product_id = products.id)").
+			Find(&products).Error
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			stock := models.WarehouseStock{
+				ProductID:   product.ID,
+				WarehouseID: warehouse.ID,
+				Quantity:    product.Quantity,
+			}
+			if err := tx.Create(&stock).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// EnsureAdmin mempromosikan user pertama menjadi admin jika belum ada admin sama sekali,
+// agar instalasi lama yang belum mengenal role tetap bisa dikelola.
+func EnsureAdmin(db *gorm.DB) error {
+	var admins int64
+	if err := db.Model(&models.User{}).Where("role = ?", models.RoleAdmin).Count(&admins).Error; err != nil {
+		return err
+	}
+	if admins > 0 {
+		return nil
+	}
+
+	var first models.User
+	err := db.Order("id ASC").First(&first).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return db.Model(&first).Update("role", models.RoleAdmin).Error
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"warehouse-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NextSequence menaikkan dan mengembalikan nomor urut berikutnya untuk name.
+// Baris sequence dikunci sampai transaksi selesai sehingga dua request tidak
+// pernah mendapatkan nomor yang sama.
+func NextSequence(tx *gorm.DB, name string) (uint64, error) {
+	seq := models.SKUSequence{Name: name}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seq).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&seq).Error; err != nil {
+		return 0, err
+	}
+
+	seq.Value++
+	if err := tx.Model(&seq).Update("value", seq.Value).Error; err != nil {
+		return 0, err
+	}
+	return seq.Value, nil
+}
@@ -43,10 +43,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.TokenResponse"
                         }
                     },
                     "400": {
@@ -66,13 +63,27 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/auth/register": {
+        "/auth/logout": {
             "post": {
-                "description": "Membuat akun user baru",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencabut access token yang sedang dipakai beserta sesi refresh token yang dikirim. Dengan all=true semua sesi user dicabut.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,21 +93,20 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Registrasi user baru",
+                "summary": "Logout",
                 "parameters": [
                     {
-                        "description": "User Data",
-                        "name": "user",
+                        "description": "Refresh token sesi ini",
+                        "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserSwagger"
+                            "$ref": "#/definitions/models.LogoutRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -104,8 +114,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -116,43 +126,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/auth/me": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all products",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengembalikan data user pemilik token beserta daftar permission dari role-nya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Auth"
                 ],
-                "summary": "Get all products",
+                "summary": "Profil user yang sedang login",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ProductSwagger"
+                            "$ref": "#/definitions/models.Profile"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new product with the input payload",
+                "description": "Menukar refresh token dengan access token dan refresh token baru. Refresh token lama langsung tidak berlaku;\nmemakai ulang refresh token lama akan mencabut seluruh sesi tersebut.",
                 "consumes": [
                     "application/json"
                 ],
@@ -160,72 +170,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Auth"
                 ],
-                "summary": "Create a new product",
+                "summary": "Perbarui access token",
                 "parameters": [
                     {
-                        "description": "Product JSON",
-                        "name": "product",
+                        "description": "Refresh token",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductSwagger"
+                            "$ref": "#/definitions/models.RefreshRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateProductResponse"
+                            "$ref": "#/definitions/models.TokenResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            }
-        },
-        "/products/barcode/{sku}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mengembalikan gambar barcode berdasarkan SKU",
-                "produces": [
-                    "image/png"
-                ],
-                "tags": [
-                    "Products"
-                ],
-                "summary": "Ambil barcode produk",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product SKU",
-                        "name": "sku",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -236,14 +212,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/bulk": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Menyimpan data produk dalam jumlah banyak menggunakan JSON array",
+                "description": "Membuat akun user baru. User pertama selalu menjadi admin. Selanjutnya tergantung REGISTRATION_MODE:\ninvite (default) mewajibkan invite_code yang valid, open membuat user viewer, disabled menolak registrasi termasuk yang memakai invite.",
                 "consumes": [
                     "application/json"
                 ],
@@ -251,20 +222,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Auth"
                 ],
-                "summary": "Menambahkan banyak produk sekaligus",
+                "summary": "Registrasi user baru",
                 "parameters": [
                     {
-                        "description": "Daftar Produk",
-                        "name": "products",
+                        "description": "User Data",
+                        "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ProductSwagger"
-                            }
+                            "$ref": "#/definitions/models.UserSwagger"
                         }
                     }
                 ],
@@ -287,8 +255,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -299,24 +267,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/dashboard": {
+        "/auth/registration": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mengambil total stok, jumlah produk, dan daftar produk dengan stok rendah",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengembalikan mode registrasi server (open, invite, disabled) agar frontend bisa menampilkan form yang sesuai",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Auth"
                 ],
-                "summary": "Mendapatkan ringkasan stok gudang",
+                "summary": "Mode registrasi",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -326,46 +286,36 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/products/export": {
+        "/invites": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengunduh daftar produk dalam format CSV",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
-                    "text/csv"
+                    "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Invites"
                 ],
-                "summary": "Ekspor daftar produk ke CSV",
+                "summary": "Daftar undangan",
                 "responses": {
                     "200": {
-                        "description": "File CSV",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Invite"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -374,16 +324,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a product by ID",
+                "description": "Membuat kode undangan untuk email dan role tertentu (khusus admin). Kode hanya ditampilkan sekali.",
                 "consumes": [
                     "application/json"
                 ],
@@ -391,110 +339,235 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Invites"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Buat undangan registrasi",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Invite JSON",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.InviteSwagger"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductSwagger"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/invites/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a product by ID",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Invites"
                 ],
-                "summary": "Update a product",
+                "summary": "Cabut undangan",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
+                        "type": "integer",
+                        "description": "Invite ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Product JSON",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProductSwagger"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateProductResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/labels": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product by ID",
+                "description": "Membuat PDF label untuk daftar produk (product_id atau sku) dengan jumlah salinan per produk.\nSetiap label berisi nama produk, barcode (vektor), SKU dan lokasi. Template lembar Avery menaruh banyak\nlabel per halaman; template thermal mencetak satu label per halaman. start_at (default 1) adalah posisi label\npertama pada lembar pertama untuk melewati label yang sudah terpakai, border menggambar garis tepi untuk mengecek posisi cetak.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Cetak label barcode sebagai PDF",
+                "parameters": [
+                    {
+                        "description": "Produk dan template label",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LabelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF label",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/labels/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Print job terbaru lebih dulu, bisa difilter dengan product_id dan status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Labels"
                 ],
-                "summary": "Delete a product",
+                "summary": "Daftar print job",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "generated, sent atau failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimum (default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PrintJob"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/labels/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Ambil print job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Print job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -504,7 +577,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.DeleteProductResponse"
+                            "$ref": "#/definitions/models.PrintJob"
                         }
                     },
                     "404": {
@@ -516,143 +589,3948 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/stock": {
-            "put": {
+        "/labels/jobs/{id}/reprint": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Change the quantity of a product by a specified amount",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengirim ulang ZPL yang tersimpan (label sama persis dengan cetakan asli) ke printer ZPL_PRINTER.\nCetak ulang dicatat sebagai print job baru dengan reprint_of_id menunjuk ke job asal.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Labels"
                 ],
-                "summary": "Update stock of a product",
+                "summary": "Cetak ulang print job",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Print job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PrintJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.PrintJob"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/labels/print": {
+            "post": {
+                "security": [
                     {
-                        "description": "Stock change request",
-                        "name": "body",
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat label ZPL seperti /labels/zpl lalu mengirimnya ke printer ZPL_PRINTER lewat TCP (port 9100).\nHasilnya dicatat sebagai print job; jika printer gagal, job berstatus failed dikembalikan dengan 502.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Cetak label ke printer Zebra",
+                "parameters": [
+                    {
+                        "description": "Produk dan template label",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.StockUpdateRequest"
+                            "$ref": "#/definitions/models.ZPLRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.PrintJob"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Bad Gateway",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.PrintJob"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.StockUpdateRequest": {
-            "type": "object",
-            "properties": {
-                "change": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.CreateProductResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Product created successfully"
-                }
-            }
         },
-        "models.DeleteProductResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Product deleted successfully"
+        "/labels/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan template label yang tersedia beserta ukurannya dalam milimeter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Daftar template label",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/utils.LabelTemplate"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "models.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Bad Request"
+        "/labels/zpl": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat label ZPL II untuk satu produk (product_id atau sku) dengan perintah barcode bawaan printer\n(^BC untuk Code 128) berisi nama, barcode, SKU dan lokasi. Hanya template thermal (satu label per halaman).\ndpi default ZPL_DPI (203). Setiap ZPL yang dibuat dicatat sebagai print job berstatus generated,\nID-nya dikirim di header X-Print-Job-ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Label ZPL untuk printer Zebra",
+                "parameters": [
+                    {
+                        "description": "Produk dan template label",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ZPLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZPL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "models.LoginCredentials": {
-            "type": "object",
+        "/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil lokasi terurut berdasarkan zone, aisle, rack, shelf dan bin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Daftar lokasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter per gudang",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter zone",
+                        "name": "zone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter aisle",
+                        "name": "aisle",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Awalan kode lokasi",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Location"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat lokasi di dalam gudang. Kode lokasi dibentuk dari kode gudang dan komponennya, lalu barcode lokasi dibuat otomatis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Tambah lokasi (zone / aisle / rack / shelf / bin)",
+                "parameters": [
+                    {
+                        "description": "Location JSON",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Ambil lokasi berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya deskripsi yang dapat diubah; kode lokasi tetap agar label yang sudah tercetak tetap valid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Update lokasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location JSON",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus lokasi yang sudah tidak menyimpan stok",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Hapus lokasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/barcode": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan gambar barcode kode lokasi untuk dicetak dan ditempel di rak",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Ambil barcode lokasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua produk yang tersimpan di sebuah lokasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Stok per lokasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LocationStock"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar produk dengan paginasi, filter dan pengurutan. Gunakan page/page_size, atau cursor dari next_cursor\nuntuk paginasi keyset yang stabil pada data besar (page diabaikan jika cursor dikirim).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (mulai 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 50, maks 200)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari next_cursor halaman sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutan, pisahkan dengan koma; awalan - untuk menurun (name, sku, quantity, location, status, created_at, updated_at, id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status, pisahkan dengan koma",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter lokasi (teks, sebagian)",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hanya produk yang memiliki stok di bin ini",
+                        "name": "location_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hanya produk yang memiliki stok di gudang ini",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantity minimum",
+                        "name": "min_quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantity maksimum",
+                        "name": "max_quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sejak (YYYY-MM-DD atau RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (inklusif)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diubah sejak",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diubah sampai (inklusif)",
+                        "name": "updated_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product with the input payload. SKU is generated from SKU_PATTERN unless provided.\nInitial quantity goes to warehouse_id (default warehouse if empty), which also fills {WH} in the SKU.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product JSON",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/barcode/{sku}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan gambar barcode berdasarkan SKU. Tanpa parameter, PNG yang tersimpan di storage aktif dikirim\n(dan dibuat ulang jika hilang). Dengan format, symbology, width, height atau dpi, barcode dirender langsung;\nformat=svg menghasilkan gambar vektor yang tetap tajam saat dicetak. width/height dalam pixel,\ndpi menentukan lebar bar (0,33 mm) dan ukuran fisik SVG. Respons memakai ETag dan Cache-Control.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Ambil barcode produk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product SKU",
+                        "name": "sku",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png atau svg (default png)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "code128, code39, ean13, upca, qr atau datamatrix (default BARCODE_SYMBOLOGY)",
+                        "name": "symbology",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lebar gambar dalam pixel",
+                        "name": "width",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tinggi gambar dalam pixel",
+                        "name": "height",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resolusi printer (72-1200)",
+                        "name": "dpi",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menyimpan data produk dalam jumlah banyak menggunakan JSON array. Setiap produk divalidasi dan dibuatkan\nSKU, status dan barcode seperti POST /products. Dengan atomic=true (default) semua produk dibatalkan jika satu\nsaja gagal; dengan atomic=false produk yang valid tetap disimpan. Response berisi hasil per indeks array.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Menambahkan banyak produk sekaligus",
+                "parameters": [
+                    {
+                        "description": "Daftar Produk",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductSwagger"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Semua atau tidak sama sekali (default true)",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Semua produk tersimpan",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkInsertResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Sebagian produk tersimpan (atomic=false)",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkInsertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Tidak ada produk yang tersimpan",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkInsertResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil total stok, jumlah produk, dan daftar produk dengan stok rendah.\nTanpa warehouse_id ringkasan diagregasi dari semua gudang beserta rincian per gudang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Mendapatkan ringkasan stok gudang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter per gudang",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/products/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh daftar produk dalam format CSV",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Ekspor daftar produk ke CSV",
+                "responses": {
+                    "200": {
+                        "description": "File CSV",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengimpor file CSV dengan format yang sama seperti /products/export (ID, Name, SKU, Quantity, Location, Status, BarcodePath).\nBaris dengan SKU yang sudah ada diperbarui, baris lain dibuat sebagai produk baru dengan SKU dan barcode otomatis\njika SKU kosong. Kolom ID, Status dan BarcodePath diabaikan. Baris yang gagal ditolak tanpa membatalkan baris lain.\nDengan dry_run=true tidak ada perubahan yang disimpan.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Impor produk dari CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validasi tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencari produk berdasarkan sebagian nama, potongan SKU atau lokasi. Hasil diurutkan menurut relevansi\n(SKU \u003e nama \u003e lokasi) dan bagian yang cocok ditandai dengan \u003cem\u003e\u003c/em\u003e. Input dari scanner yang salah\nsatu-dua karakter tetap ditemukan lewat pencocokan awalan dengan toleransi salah ketik.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Cari produk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah hasil maksimum (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a product by ID. Changing the SKU regenerates its barcode image and removes the old one. Quantity cannot be changed here; use PUT /products/{id}/stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field yang diubah",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versi produk yang diharapkan (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/barcodes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Daftar barcode alternatif produk",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductBarcode"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan barcode pabrik (EAN/UPC) atau barcode karton. Kode GS1 (ean8, upca, ean13, itf14) divalidasi check\ndigit-nya; symbology kosong ditebak dari kode. Kode harus unik di semua produk dan lokasi, termasuk terhadap SKU, kode lokasi dan bentuk\nGTIN yang setara. pack_quantity adalah jumlah unit yang dihitung setiap kali barcode ini di-scan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Tambah barcode alternatif produk",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Barcode",
+                        "name": "barcode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductBarcodeSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductBarcode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/barcodes/{barcodeId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Ubah barcode alternatif produk",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Barcode ID",
+                        "name": "barcodeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Barcode",
+                        "name": "barcode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductBarcodeSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductBarcode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Hapus barcode alternatif produk",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Barcode ID",
+                        "name": "barcodeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil ledger pergerakan stok sebuah produk, dapat difilter berdasarkan rentang tanggal (YYYY-MM-DD atau RFC3339)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Riwayat pergerakan stok produk",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (inklusif)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (inklusif)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter per gudang",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Rincian stok produk per gudang dan per lokasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the quantity of a product by a specified amount and record it in the stock movement ledger.\nDecrements beyond the available quantity follow the product's negative stock policy (reject, allow or clamp).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update stock of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock change request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.StockUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scan/image": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membaca foto atau screenshot label (JPEG/PNG, maks 10 MB, field \"file\") lalu mendekode Code 128, Code 39,\nEAN/UPC, ITF, QR dan Data Matrix di dalamnya. Setiap kode dicocokkan seperti GET /scan/{code}; match null\njika kode tidak dikenal. 422 jika tidak ada barcode yang terbaca.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scan"
+                ],
+                "summary": "Dekode barcode dari gambar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Gambar JPEG atau PNG",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageScanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scan/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencocokkan kode mentah dari scanner dengan SKU produk (persis lalu tanpa beda huruf besar), barcode alternatif\nproduk, GTIN setara (UPC-A 12 digit, EAN-13 dan GTIN-14 termasuk awalan GS1 01) dan kode lokasi. Awalan AIM\n(]C1, ]E0, ...) serta CR/LF dari scanner dibuang. Produk dikembalikan beserta stok per gudang dan pack_quantity\n(jumlah unit per scan, misalnya isi karton), lokasi beserta isinya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scan"
+                ],
+                "summary": "Cari produk atau lokasi dari hasil scan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode hasil scan",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScanResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencari produk dari kode hasil scan seperti GET /scan/{code} lalu langsung mengubah stoknya sebesar\nchange x pack_quantity dalam satu transaksi. Lokasi bisa dikirim sebagai location_id atau location_code hasil scan bin.\nReference default \"scan:\u003ckode\u003e\". Kebijakan stok negatif produk tetap berlaku.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scan"
+                ],
+                "summary": "Scan lalu sesuaikan stok",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode hasil scan",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Perubahan stok",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScanAdjustRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Daftar transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status (draft, in_transit, received)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transfer yang berasal dari atau menuju gudang ini",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transfer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat transfer berstatus draft dari gudang/lokasi asal ke gudang/lokasi tujuan. Stok belum berubah sampai transfer di-dispatch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Buat dokumen transfer stok",
+                "parameters": [
+                    {
+                        "description": "Transfer JSON",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Ambil transfer berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Hapus transfer draft",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/dispatch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengurangi stok di asal untuk setiap baris dan mengubah status menjadi in_transit. Stok harus mencukupi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Kirim transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil movement transfer_out dan transfer_in yang ditulis oleh transfer ini",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Pergerakan stok milik transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambah stok di tujuan untuk setiap baris dan mengubah status menjadi received",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Terima transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua user beserta role-nya (khusus admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Daftar user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus user (soft delete) dan mencabut semua sesinya. Token yang masih berlaku langsung ditolak (khusus admin).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Hapus user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menetapkan role admin, manager, operator atau viewer (khusus admin). Role baru langsung berlaku.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Ubah role user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menonaktifkan user (active=false) langsung menolak token yang masih berlaku dan mencabut semua sesinya (khusus admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Aktifkan / nonaktifkan user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserStatusUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Daftar gudang",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Warehouse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat gudang baru dengan kode unik",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Tambah gudang",
+                "parameters": [
+                    {
+                        "description": "Warehouse JSON",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WarehouseSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Ambil gudang berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah nama dan alamat gudang. Kode gudang tidak dapat diubah.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Update gudang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse JSON",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WarehouseSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus gudang yang sudah tidak memiliki stok maupun lokasi. Gudang default tidak dapat dihapus.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Hapus gudang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil stok semua produk pada sebuah gudang",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Stok per gudang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WarehouseStock"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}/stock/{product_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambah atau mengurangi stok produk pada gudang dan mencatatnya di ledger",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Ubah stok produk di gudang tertentu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock change request (warehouse_id diabaikan)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.StockUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.StockUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "integer",
+                    "example": -5
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "pick"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "SO-2024-0001"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BulkInsertResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkRowResult"
+                    }
+                }
+            }
+        },
+        "models.BulkRowResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Name is required"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-1700000000000"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "created"
+                }
+            }
+        },
+        "models.CreateProductResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Product created successfully"
+                }
+            }
+        },
+        "models.DeleteProductResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Product deleted successfully"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Bad Request"
+                }
+            }
+        },
+        "models.ImageScanResponse": {
+            "type": "object",
+            "properties": {
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImageScanResult"
+                    }
+                }
+            }
+        },
+        "models.ImageScanResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "4006381333931"
+                },
+                "match": {
+                    "$ref": "#/definitions/models.ScanResult"
+                },
+                "symbology": {
+                    "type": "string",
+                    "example": "ean13"
+                }
+            }
+        },
+        "models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowResult"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "created"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Quantity must be a number"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-1700000000000"
+                }
+            }
+        },
+        "models.Invite": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "operator@example.com"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "operator"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "used_at": {
+                    "type": "string"
+                },
+                "used_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.InviteSwagger": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "operator@example.com"
+                },
+                "expires_in_hours": {
+                    "type": "integer",
+                    "example": 72
+                },
+                "role": {
+                    "type": "string",
+                    "example": "operator"
+                }
+            }
+        },
+        "models.LabelItem": {
+            "type": "object",
+            "properties": {
+                "copies": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-2024-000001"
+                }
+            }
+        },
+        "models.LabelRequest": {
+            "type": "object",
+            "properties": {
+                "border": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabelItem"
+                    }
+                },
+                "start_at": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "symbology": {
+                    "type": "string",
+                    "example": "code128"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "avery-l7160"
+                }
+            }
+        },
+        "models.Location": {
+            "type": "object",
+            "properties": {
+                "aisle": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "barcode_path": {
+                    "type": "string"
+                },
+                "bin": {
+                    "type": "string",
+                    "example": "04"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MAIN-A-01-02-03-04"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Rak barang kecil"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rack": {
+                    "type": "string",
+                    "example": "02"
+                },
+                "shelf": {
+                    "type": "string",
+                    "example": "03"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "warehouse": {
+                    "$ref": "#/definitions/models.Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "zone": {
+                    "type": "string",
+                    "example": "A"
+                }
+            }
+        },
+        "models.LocationStock": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LocationSwagger": {
+            "type": "object",
+            "properties": {
+                "aisle": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "bin": {
+                    "type": "string",
+                    "example": "04"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Rak barang kecil"
+                },
+                "rack": {
+                    "type": "string",
+                    "example": "02"
+                },
+                "shelf": {
+                    "type": "string",
+                    "example": "03"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "zone": {
+                    "type": "string",
+                    "example": "A"
+                }
+            }
+        },
+        "models.LoginCredentials": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin1@email.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "bismillah"
+                }
+            }
+        },
+        "models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "6b1f0c..."
+                }
+            }
+        },
+        "models.PrintJob": {
+            "type": "object",
+            "properties": {
+                "copies": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dpi": {
+                    "type": "integer",
+                    "example": 203
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "printer": {
+                    "type": "string",
+                    "example": "10.0.0.50:9100"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reprint_of_id": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-2024-000001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "sent"
+                },
+                "symbology": {
+                    "type": "string",
+                    "example": "code128"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "thermal-2x1"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "zpl": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "barcode_path": {
+                    "type": "string"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductBarcode"
+                    }
+                },
+                "category": {
+                    "type": "string",
+                    "example": "ELEC"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Rak 1"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Produk A"
+                },
+                "negative_stock_policy": {
+                    "type": "string",
+                    "example": "reject"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU123"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "available"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_id": {
+                    "description": "WarehouseID hanya dipakai saat produk dibuat: gudang untuk stok awal dan {WH} di SKU_PATTERN.\n0 berarti gudang default. Tidak disimpan karena stok per gudang ada di WarehouseStock.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProductBarcode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "8991234567890"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Karton isi 12"
+                },
+                "gtin": {
+                    "description": "GTIN-14, hanya untuk symbology GS1",
+                    "type": "string",
+                    "example": "08991234567890"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "pack_quantity": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "symbology": {
+                    "type": "string",
+                    "example": "ean13"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductBarcodeSwagger": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "8991234567890"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Karton isi 12"
+                },
+                "pack_quantity": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "symbology": {
+                    "type": "string",
+                    "example": "ean13"
+                }
+            }
+        },
+        "models.ProductPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLXF1YW50aXR5In0"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1234
+                }
+            }
+        },
+        "models.ProductSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSearchResult"
+                    }
+                },
+                "query": {
+                    "type": "string",
+                    "example": "kabel usb"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.ProductSearchResult": {
+            "type": "object",
+            "properties": {
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 12.5
+                }
+            }
+        },
+        "models.ProductSwagger": {
+            "description": "Product represents a product in the warehouse",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "ELEC"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Rak 1"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Produk A"
+                },
+                "negative_stock_policy": {
+                    "type": "string",
+                    "example": "reject"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "ELEC-2024-000001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "available"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ProductUpdate": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "ELEC"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Rak 1"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Produk A"
+                },
+                "negative_stock_policy": {
+                    "type": "string",
+                    "example": "reject"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "ELEC-2024-000001"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.Profile": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products:read",
+                        "stock:adjust"
+                    ]
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.RefreshRequest": {
+            "type": "object",
             "properties": {
-                "email": {
+                "refresh_token": {
                     "type": "string",
-                    "example": "admin1@email.com"
+                    "example": "6b1f0c..."
+                }
+            }
+        },
+        "models.RoleUpdate": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "operator"
+                }
+            }
+        },
+        "models.ScanAdjustRequest": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "integer",
+                    "example": -1
                 },
-                "password": {
+                "location_code": {
                     "type": "string",
-                    "example": "bismillah"
+                    "example": "MAIN-A-01-02"
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "pick"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "SO-2024-0001"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "models.ProductSwagger": {
-            "description": "Product represents a product in the warehouse",
+        "models.ScanResult": {
             "type": "object",
             "properties": {
+                "barcode": {
+                    "$ref": "#/definitions/models.ProductBarcode"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SKU-2024-000001"
+                },
+                "contents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LocationStock"
+                    }
+                },
                 "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "matched_by": {
                     "type": "string",
-                    "example": "Rak 1"
+                    "example": "sku"
                 },
-                "name": {
+                "pack_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "stock": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WarehouseStock"
+                    }
+                },
+                "type": {
                     "type": "string",
-                    "example": "Produk A"
+                    "example": "product"
+                }
+            }
+        },
+        "models.StockMovement": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "quantity": {
+                "delta": {
                     "type": "integer",
-                    "example": 100
+                    "example": -5
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity_after": {
+                    "type": "integer",
+                    "example": 95
+                },
+                "reason_code": {
+                    "type": "string",
+                    "example": "pick"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "SO-2024-0001"
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "6b1f0c..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOi..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "models.Transfer": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "destination_location_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "destination_warehouse_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "dispatched_at": {
+                    "type": "string"
+                },
+                "dispatched_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransferLine"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Restock cabang"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "TRF-000001"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "integer"
+                },
+                "source_location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "source_warehouse_id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "status": {
                     "type": "string",
-                    "example": "available"
+                    "example": "draft"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransferLine": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "transfer_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TransferLineSwagger": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.TransferSwagger": {
+            "type": "object",
+            "properties": {
+                "destination_location_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "destination_warehouse_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransferLineSwagger"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Restock cabang"
+                },
+                "source_location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "source_warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "viewer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserStatusUpdate": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -663,6 +4541,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "mail@example.com"
                 },
+                "invite_code": {
+                    "type": "string",
+                    "example": "3f9c2a..."
+                },
                 "name": {
                     "type": "string",
                     "example": "John Doe"
@@ -672,6 +4554,165 @@ const docTemplate = `{
                     "example": "password123"
                 }
             }
+        },
+        "models.Warehouse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Industri No. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gudang Utama"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WarehouseStock": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse": {
+                    "$ref": "#/definitions/models.Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.WarehouseSwagger": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Industri No. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gudang Utama"
+                }
+            }
+        },
+        "models.ZPLRequest": {
+            "type": "object",
+            "properties": {
+                "copies": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "dpi": {
+                    "type": "integer",
+                    "example": 203
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-2024-000001"
+                },
+                "symbology": {
+                    "type": "string",
+                    "example": "code128"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "thermal-2x1"
+                }
+            }
+        },
+        "utils.LabelTemplate": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "description": {
+                    "type": "string",
+                    "example": "A4, 21 label 63.5 x 38.1 mm"
+                },
+                "label_height": {
+                    "type": "number",
+                    "example": 38.1
+                },
+                "label_width": {
+                    "type": "number",
+                    "example": 63.5
+                },
+                "margin_left": {
+                    "type": "number",
+                    "example": 7.2
+                },
+                "margin_top": {
+                    "type": "number",
+                    "example": 15.15
+                },
+                "name": {
+                    "type": "string",
+                    "example": "avery-l7160"
+                },
+                "page_height": {
+                    "type": "number",
+                    "example": 297
+                },
+                "page_width": {
+                    "type": "number",
+                    "example": 210
+                },
+                "pitch_x": {
+                    "description": "jarak antar awal kolom",
+                    "type": "number",
+                    "example": 66
+                },
+                "pitch_y": {
+                    "description": "jarak antar awal baris",
+                    "type": "number",
+                    "example": 38.1
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,75 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// BarcodeBatch mengumpulkan perubahan gambar barcode selama transaksi database. Gambar sudah
+// dirender saat dicatat sehingga kode yang tidak bisa di-encode membatalkan transaksinya, tetapi
+// baru ditulis atau dihapus dari storage lewat Apply setelah transaksi berhasil di-commit, sehingga
+// transaksi yang dibatalkan tidak meninggalkan file yatim atau menghapus file yang masih dipakai.
+type BarcodeBatch struct {
+	generate []renderedBarcode
+	remove   []string
+}
+
+// renderedBarcode adalah PNG yang menunggu ditulis oleh Apply
+type renderedBarcode struct {
+	code string
+	data []byte
+}
+
+// Generate merender barcode code dan mencatatnya untuk ditulis, lalu mengembalikan key-nya untuk
+// BarcodePath. Error-nya membungkus ErrBarcodeEncode jika code tidak bisa dirender.
+func (b *BarcodeBatch) Generate(code string) (string, error) {
+	data, err := RenderBarcode(code)
+	if err != nil {
+		return "", err
+	}
+	b.add(renderedBarcode{code: code, data: data})
+	return BarcodeKey(code), nil
+}
+
+func (b *BarcodeBatch) add(barcode renderedBarcode) {
+	b.remove = slices.DeleteFunc(b.remove, func(c string) bool { return c == barcode.code })
+	b.generate = slices.DeleteFunc(b.generate, func(r renderedBarcode) bool { return r.code == barcode.code })
+	b.generate = append(b.generate, barcode)
+}
+
+// Delete mencatat bahwa barcode code tidak dipakai lagi
+func (b *BarcodeBatch) Delete(code string) {
+	b.generate = slices.DeleteFunc(b.generate, func(r renderedBarcode) bool { return r.code == code })
+	if !slices.Contains(b.remove, code) {
+		b.remove = append(b.remove, code)
+	}
+}
+
+// Merge menambahkan perubahan dari batch lain, misalnya dari savepoint yang berhasil
+func (b *BarcodeBatch) Merge(other BarcodeBatch) {
+	for _, code := range other.remove {
+		b.Delete(code)
+	}
+	for _, barcode := range other.generate {
+		b.add(barcode)
+	}
+}
+
+// Apply menulis dan menghapus gambar barcode di store aktif. Semua perubahan tetap dicoba
+// walaupun ada yang gagal; error-nya digabung.
+func (b *BarcodeBatch) Apply(ctx context.Context) error {
+	var errs []error
+	for _, barcode := range b.generate {
+		if err := Barcodes.Put(ctx, BarcodeKey(barcode.code), barcode.data, "image/png"); err != nil {
+			errs = append(errs, fmt.Errorf("generate %s: %w", barcode.code, err))
+		}
+	}
+	for _, code := range b.remove {
+		if err := DeleteBarcode(ctx, code); err != nil {
+			errs = append(errs, fmt.Errorf("delete %s: %w", code, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"warehouse-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseStore menyimpan object sebagai blob di tabel stored_files sehingga semua replika
+// API melihat file yang sama tanpa volume bersama atau object storage
+type DatabaseStore struct {
+	db *gorm.DB
+}
+
+// NewDatabaseStore membuat store yang memakai koneksi db
+func NewDatabaseStore(db *gorm.DB) *DatabaseStore {
+	return &DatabaseStore{db: db}
+}
+
+func (s *DatabaseStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	file := models.StoredFile{Path: key, ContentType: contentType, Size: int64(len(data)), Data: data}
+	return s.db.WithContext(ctx).Save(&file).Error
+}
+
+func (s *DatabaseStore) Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	var file models.StoredFile
+	if err := s.db.WithContext(ctx).Where("path = ?", key).First(&file).Error; err != nil {
+		return nil, ObjectInfo{}, recordError(err)
+	}
+	return io.NopCloser(bytes.NewReader(file.Data)), storedInfo(file), nil
+}
+
+func (s *DatabaseStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	var file models.StoredFile
+	err := s.db.WithContext(ctx).Select("path", "content_type", "size", "updated_at").
+		Where("path = ?", key).First(&file).Error
+	if err != nil {
+		return ObjectInfo{}, recordError(err)
+	}
+	return storedInfo(file), nil
+}
+
+func (s *DatabaseStore) Delete(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("path = ?", key).Delete(&models.StoredFile{}).Error
+}
+
+func (s *DatabaseStore) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	err := s.db.WithContext(ctx).Model(&models.StoredFile{}).Where("path LIKE ?", prefix+"%").Order("path").Pluck("path", &keys).Error
+	return keys, err
+}
+
+func recordError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func storedInfo(file models.StoredFile) ObjectInfo {
+	return ObjectInfo{Size: file.Size, ModTime: file.UpdatedAt, ContentType: file.ContentType}
+}
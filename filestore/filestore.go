@@ -0,0 +1,128 @@
+// Package filestore menyimpan file biner aplikasi (gambar barcode) di backend yang dipilih
+// lewat BARCODE_STORAGE: filesystem lokal, tabel database atau object storage S3.
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"warehouse-backend/utils"
+
+	"gorm.io/gorm"
+)
+
+// Backend yang bisa dipilih lewat BARCODE_STORAGE
+const (
+	BackendFilesystem = "filesystem"
+	BackendDatabase   = "database"
+	BackendS3         = "s3"
+)
+
+// DefaultDir adalah folder root backend filesystem
+const DefaultDir = "storage"
+
+// ErrNotFound dikembalikan jika object tidak ada di storage
+var ErrNotFound = errors.New("object not found")
+
+// ErrBarcodeEncode dikembalikan jika kode tidak bisa dirender dengan BARCODE_SYMBOLOGY aktif,
+// misalnya SKU berhuruf dengan symbology ean13
+var ErrBarcodeEncode = errors.New("cannot encode barcode")
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Size        int64
+	ModTime     time.Time
+	ContentType string
+}
+
+// Store adalah backend penyimpanan file. Key memakai garis miring sebagai pemisah
+// (misalnya "barcodes/SKU-1.png") apa pun backend-nya.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Open membuka object untuk dibaca; pemanggil wajib menutup reader
+	Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete tidak mengembalikan error jika object memang tidak ada
+	Delete(ctx context.Context, key string) error
+	// List mengembalikan semua key yang diawali prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Barcodes adalah store aktif untuk gambar barcode, diganti oleh Setup sesuai konfigurasi
+var Barcodes Store = NewFileStore(DefaultDir)
+
+// Setup memilih backend dari BARCODE_STORAGE (filesystem, database atau s3). Backend database
+// menyimpan file di tabel stored_files sehingga butuh koneksi db.
+func Setup(db *gorm.DB) error {
+	store, err := newStore(utils.GetEnv("BARCODE_STORAGE", BackendFilesystem), db)
+	if err != nil {
+		return err
+	}
+	Barcodes = store
+	return nil
+}
+
+func newStore(backend string, db *gorm.DB) (Store, error) {
+	switch backend {
+	case BackendFilesystem:
+		return NewFileStore(utils.GetEnv("BARCODE_STORAGE_DIR", DefaultDir)), nil
+	case BackendDatabase:
+		return NewDatabaseStore(db), nil
+	case BackendS3:
+		useSSL, err := strconv.ParseBool(utils.GetEnv("S3_USE_SSL", "true"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3_USE_SSL: %w", err)
+		}
+		return NewS3Store(S3Config{
+			Endpoint:  utils.GetEnv("S3_ENDPOINT", ""),
+			Bucket:    utils.GetEnv("S3_BUCKET", ""),
+			AccessKey: utils.GetEnv("S3_ACCESS_KEY", ""),
+			SecretKey: utils.GetEnv("S3_SECRET_KEY", ""),
+			Region:    utils.GetEnv("S3_REGION", "us-east-1"),
+			Prefix:    utils.GetEnv("S3_PREFIX", ""),
+			UseSSL:    useSSL,
+		})
+	}
+	return nil, fmt.Errorf("unsupported BARCODE_STORAGE %q", backend)
+}
+
+// BarcodePrefix adalah awalan key semua gambar barcode
+const BarcodePrefix = "barcodes/"
+
+// BarcodeKey adalah key gambar barcode untuk sebuah kode (SKU atau kode lokasi)
+func BarcodeKey(code string) string {
+	return BarcodePrefix + code + ".png"
+}
+
+// RenderBarcode merender PNG barcode sebuah kode dengan symbology default
+func RenderBarcode(code string) ([]byte, error) {
+	symbology := utils.DefaultSymbology()
+	data, err := utils.BarcodePNG(code, utils.DefaultBarcodeOptions(symbology))
+	if err != nil {
+		return nil, fmt.Errorf("%w %q as %s: %v", ErrBarcodeEncode, code, symbology, err)
+	}
+	return data, nil
+}
+
+// GenerateBarcode membuat barcode PNG dari kode dengan symbology default, menyimpannya
+// di store aktif dan mengembalikan key-nya untuk disimpan di BarcodePath
+func GenerateBarcode(ctx context.Context, code string) (string, error) {
+	data, err := RenderBarcode(code)
+	if err != nil {
+		return "", err
+	}
+
+	key := BarcodeKey(code)
+	if err := Barcodes.Put(ctx, key, data, "image/png"); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// DeleteBarcode menghapus gambar barcode sebuah kode dari store aktif
+func DeleteBarcode(ctx context.Context, code string) error {
+	return Barcodes.Delete(ctx, BarcodeKey(code))
+}
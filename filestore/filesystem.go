@@ -0,0 +1,144 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore menyimpan object sebagai file di bawah folder root. Cocok untuk satu server
+// atau beberapa replika yang berbagi volume yang sama.
+type FileStore struct {
+	root string
+}
+
+// NewFileStore membuat store filesystem dengan folder root dir
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{root: dir}
+}
+
+// path mengubah key menjadi path file; key dengan ".." atau path absolut ditolak
+func (s *FileStore) path(key string) (string, error) {
+	if !fs.ValidPath(key) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put menulis ke file sementara lalu me-rename-nya agar pembaca tidak pernah melihat file setengah jadi
+func (s *FileStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, ObjectInfo{}, fileError(err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, ObjectInfo{}, err
+	}
+	return file, fileInfo(info, path), nil
+}
+
+func (s *FileStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ObjectInfo{}, fileError(err)
+	}
+	return fileInfo(info, path), nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List menelusuri folder prefix; file sementara dari Put yang terputus dilewati
+func (s *FileStore) List(ctx context.Context, prefix string) ([]string, error) {
+	dir, err := s.path(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(rel))
+		return nil
+	})
+	return keys, err
+}
+
+// fileError menyeragamkan error file yang tidak ada menjadi ErrNotFound
+func fileError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func fileInfo(info os.FileInfo, path string) ObjectInfo {
+	contentType := "application/octet-stream"
+	if filepath.Ext(path) == ".png" {
+		contentType = "image/png"
+	}
+	return ObjectInfo{Size: info.Size(), ModTime: info.ModTime(), ContentType: contentType}
+}
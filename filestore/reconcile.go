@@ -0,0 +1,126 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"warehouse-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ReconcileReport is the outcome of ReconcileBarcodes. On a dry run the lists
+// contain what would be fixed.
+type ReconcileReport struct {
+	Checked      int
+	Missing      []string
+	Orphans      []string
+	PathsUpdated int
+	Errors       []string
+}
+
+// barcodeOwner adalah baris produk atau lokasi yang memiliki gambar barcode
+type barcodeOwner struct {
+	ID          uint
+	Code        string
+	BarcodePath string
+}
+
+// ReconcileBarcodes mencocokkan gambar barcode di store aktif dengan produk dan lokasi di database,
+// termasuk yang sudah soft-delete agar tetap punya barcode saat dipulihkan. Gambar yang hilang dibuat
+// ulang, gambar yatim (tanpa produk atau lokasi) dihapus dan barcode_path yang berbeda dari key-nya
+// diperbarui. Dengan dryRun tidak ada yang diubah.
+//
+// Store di-list sebelum database dibaca: gambar baru ditulis setelah transaksinya di-commit, jadi
+// setiap key yang ter-list dan masih dipakai pasti sudah terlihat sebagai pemilik. Gambar yatim yang
+// ditulis ulang setelah reconcile mulai (kodenya dipakai lagi) juga dilewati, sehingga perintah ini
+// boleh dijalankan saat API hidup. Jika gambar tetap terhapus pada sisa celah waktu yang sangat kecil,
+// gambar tersebut dibuat ulang saat diminta.
+func ReconcileBarcodes(ctx context.Context, db *gorm.DB, dryRun bool) (ReconcileReport, error) {
+	report := ReconcileReport{Missing: []string{}, Orphans: []string{}, Errors: []string{}}
+	start := time.Now()
+
+	keys, err := Barcodes.List(ctx, BarcodePrefix)
+	if err != nil {
+		return report, err
+	}
+
+	owners := map[string][]barcodeOwner{}
+	for _, table := range []struct {
+		model  interface{}
+		column string
+	}{
+		{&models.Product{}, "sku"},
+		{&models.Location{}, "code"},
+	} {
+		var rows []barcodeOwner
+		err := db.WithContext(ctx).Unscoped().Model(table.model).
+			Select("id, " + table.column + " AS code, barcode_path").Order("id").Scan(&rows).Error
+		if err != nil {
+			return report, err
+		}
+		report.Checked += len(rows)
+
+		for _, row := range rows {
+			key := BarcodeKey(row.Code)
+			owners[key] = append(owners[key], row)
+			if row.BarcodePath == key {
+				continue
+			}
+			report.PathsUpdated++
+			if dryRun {
+				continue
+			}
+			// Kode ikut dicocokkan agar SKU yang baru saja diubah tidak ditimpa dengan key lama
+			err := db.WithContext(ctx).Unscoped().Model(table.model).
+				Where("id = ? AND "+table.column+" = ?", row.ID, row.Code).
+				UpdateColumn("barcode_path", key).Error
+			if err != nil {
+				return report, err
+			}
+		}
+	}
+
+	stored := map[string]bool{}
+	var orphans []string
+	for _, key := range keys {
+		stored[key] = true
+		if _, ok := owners[key]; !ok {
+			orphans = append(orphans, key)
+		}
+	}
+	for key, rows := range owners {
+		if !stored[key] {
+			report.Missing = append(report.Missing, key)
+			if !dryRun {
+				if _, err := GenerateBarcode(ctx, rows[0].Code); err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("generate %s: %v", key, err))
+				}
+			}
+		}
+	}
+	sort.Strings(report.Missing)
+
+	for _, key := range orphans {
+		if dryRun {
+			report.Orphans = append(report.Orphans, key)
+			continue
+		}
+
+		// Key yang ditulis ulang setelah reconcile mulai sudah dipakai lagi
+		info, err := Barcodes.Stat(ctx, key)
+		if errors.Is(err, ErrNotFound) || (err == nil && info.ModTime.After(start)) {
+			continue
+		}
+		report.Orphans = append(report.Orphans, key)
+		if err == nil {
+			err = Barcodes.Delete(ctx, key)
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("delete %s: %v", key, err))
+		}
+	}
+	return report, nil
+}
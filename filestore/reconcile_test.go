@@ -0,0 +1,96 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"warehouse-backend/models"
+)
+
+func TestReconcileBarcodes(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	previous := Barcodes
+	Barcodes = NewFileStore(t.TempDir())
+	t.Cleanup(func() { Barcodes = previous })
+
+	// A-1 punya gambar, B-1 (soft delete) dan lokasi MAIN-A tidak; GONE tidak punya pemilik
+	products := []models.Product{
+		{Name: "A", SKU: "A-1", Status: "Available", BarcodePath: BarcodeKey("A-1")},
+		{Name: "B", SKU: "B-1", Status: "Available", BarcodePath: "barcodes/OLD.png"},
+	}
+	if err := db.Create(&products).Error; err != nil {
+		t.Fatalf("create products: %v", err)
+	}
+	if err := db.Delete(&products[1]).Error; err != nil {
+		t.Fatalf("delete product: %v", err)
+	}
+	if err := db.Create(&models.Location{WarehouseID: 1, Code: "MAIN-A", Zone: "A"}).Error; err != nil {
+		t.Fatalf("create location: %v", err)
+	}
+	for _, code := range []string{"A-1", "GONE"} {
+		if _, err := GenerateBarcode(ctx, code); err != nil {
+			t.Fatalf("generate %s: %v", code, err)
+		}
+	}
+
+	wantMissing := []string{"barcodes/B-1.png", "barcodes/MAIN-A.png"}
+	wantOrphans := []string{"barcodes/GONE.png"}
+
+	report, err := ReconcileBarcodes(ctx, db, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if report.Checked != 3 || report.PathsUpdated != 2 {
+		t.Errorf("dry run checked %d, paths %d; want 3, 2", report.Checked, report.PathsUpdated)
+	}
+	if !reflect.DeepEqual(report.Missing, wantMissing) || !reflect.DeepEqual(report.Orphans, wantOrphans) {
+		t.Errorf("dry run missing %v, orphans %v; want %v, %v", report.Missing, report.Orphans, wantMissing, wantOrphans)
+	}
+	// Dry run tidak mengubah apa pun
+	if _, err := Barcodes.Stat(ctx, "barcodes/GONE.png"); err != nil {
+		t.Errorf("dry run removed orphan: %v", err)
+	}
+	if _, err := Barcodes.Stat(ctx, "barcodes/B-1.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("dry run generated missing image: %v", err)
+	}
+
+	report, err = ReconcileBarcodes(ctx, db, false)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(report.Errors) != 0 {
+		t.Fatalf("reconcile errors: %v", report.Errors)
+	}
+	if !reflect.DeepEqual(report.Missing, wantMissing) || !reflect.DeepEqual(report.Orphans, wantOrphans) {
+		t.Errorf("reconcile missing %v, orphans %v; want %v, %v", report.Missing, report.Orphans, wantMissing, wantOrphans)
+	}
+
+	keys, err := Barcodes.List(ctx, BarcodePrefix)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	wantKeys := []string{"barcodes/A-1.png", "barcodes/B-1.png", "barcodes/MAIN-A.png"}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("stored keys %v, want %v", keys, wantKeys)
+	}
+
+	var restored models.Product
+	if err := db.Unscoped().First(&restored, products[1].ID).Error; err != nil {
+		t.Fatalf("load product: %v", err)
+	}
+	if restored.BarcodePath != BarcodeKey("B-1") {
+		t.Errorf("barcode_path = %q, want %q", restored.BarcodePath, BarcodeKey("B-1"))
+	}
+
+	// Setelah diperbaiki, reconcile berikutnya tidak menemukan apa-apa
+	report, err = ReconcileBarcodes(ctx, db, false)
+	if err != nil {
+		t.Fatalf("second reconcile: %v", err)
+	}
+	if len(report.Missing) != 0 || len(report.Orphans) != 0 || report.PathsUpdated != 0 {
+		t.Errorf("second reconcile found missing %v, orphans %v, paths %d", report.Missing, report.Orphans, report.PathsUpdated)
+	}
+}
@@ -0,0 +1,122 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config berisi koneksi ke object storage S3 atau yang kompatibel (MinIO, Ceph, R2, ...)
+type S3Config struct {
+	Endpoint  string // host[:port] tanpa skema, misalnya s3.amazonaws.com atau localhost:9000
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Region    string
+	// Prefix ditambahkan di depan setiap key, misalnya "warehouse/"
+	Prefix string
+	UseSSL bool
+}
+
+// S3Store menyimpan object di bucket S3
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store membuat client S3 dan memastikan bucket-nya ada
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required")
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(context.Background(), cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach S3 bucket %q: %w", cfg.Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("S3 bucket %q does not exist", cfg.Bucket)
+	}
+
+	prefix := strings.Trim(cfg.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &S3Store{client: client, bucket: cfg.Bucket, prefix: prefix}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.prefix+key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, s.prefix+key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, s3Error(err)
+	}
+	// GetObject baru menghubungi server saat dibaca; Stat memastikan object-nya ada
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, ObjectInfo{}, s3Error(err)
+	}
+	return object, s3Info(info), nil
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, s.prefix+key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, s3Error(err)
+	}
+	return s3Info(info), nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	return s3Error(s.client.RemoveObject(ctx, s.bucket, s.prefix+key, minio.RemoveObjectOptions{}))
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.prefix + prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, strings.TrimPrefix(object.Key, s.prefix))
+	}
+	return keys, nil
+}
+
+// s3Error menyeragamkan object yang tidak ada menjadi ErrNotFound
+func s3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	response := minio.ToErrorResponse(err)
+	if response.Code == "NoSuchKey" || response.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+func s3Info(info minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{Size: info.Size, ModTime: info.LastModified, ContentType: info.ContentType}
+}
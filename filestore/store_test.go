@@ -0,0 +1,164 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+	"warehouse-backend/models"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testStoreContract menjalankan perilaku yang sama terhadap setiap backend Store
+func testStoreContract(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	t.Run("missing key", func(t *testing.T) {
+		if _, _, err := store.Open(ctx, "barcodes/MISSING.png"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Open missing key: got %v, want ErrNotFound", err)
+		}
+		if _, err := store.Stat(ctx, "barcodes/MISSING.png"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Stat missing key: got %v, want ErrNotFound", err)
+		}
+		if err := store.Delete(ctx, "barcodes/MISSING.png"); err != nil {
+			t.Fatalf("Delete missing key: %v", err)
+		}
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		if err := store.Put(ctx, "barcodes/A-1.png", []byte("first"), "image/png"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		// Put kedua menimpa isi lama
+		if err := store.Put(ctx, "barcodes/A-1.png", []byte("second"), "image/png"); err != nil {
+			t.Fatalf("Put overwrite: %v", err)
+		}
+
+		reader, info, err := store.Open(ctx, "barcodes/A-1.png")
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if string(data) != "second" {
+			t.Errorf("Open data = %q, want %q", data, "second")
+		}
+		if info.Size != int64(len("second")) {
+			t.Errorf("Open size = %d, want %d", info.Size, len("second"))
+		}
+
+		info, err = store.Stat(ctx, "barcodes/A-1.png")
+		if err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+		if info.Size != int64(len("second")) {
+			t.Errorf("Stat size = %d, want %d", info.Size, len("second"))
+		}
+		if info.ContentType != "image/png" {
+			t.Errorf("Stat content type = %q, want image/png", info.ContentType)
+		}
+		if info.ModTime.IsZero() {
+			t.Error("Stat mod time is zero")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		for _, key := range []string{"barcodes/B-1.png", "labels/B-1.pdf"} {
+			if err := store.Put(ctx, key, []byte("x"), "application/octet-stream"); err != nil {
+				t.Fatalf("Put %s: %v", key, err)
+			}
+		}
+
+		keys, err := store.List(ctx, BarcodePrefix)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		want := []string{"barcodes/A-1.png", "barcodes/B-1.png"}
+		if !reflect.DeepEqual(keys, want) {
+			t.Errorf("List = %v, want %v", keys, want)
+		}
+
+		keys, err = store.List(ctx, "nothing/")
+		if err != nil {
+			t.Fatalf("List empty prefix: %v", err)
+		}
+		if len(keys) != 0 {
+			t.Errorf("List empty prefix = %v, want none", keys)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for _, key := range []string{"barcodes/A-1.png", "barcodes/B-1.png", "labels/B-1.pdf"} {
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete %s: %v", key, err)
+			}
+		}
+		if _, err := store.Stat(ctx, "barcodes/A-1.png"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Stat after delete: got %v, want ErrNotFound", err)
+		}
+		keys, err := store.List(ctx, BarcodePrefix)
+		if err != nil {
+			t.Fatalf("List after delete: %v", err)
+		}
+		if len(keys) != 0 {
+			t.Errorf("List after delete = %v, want none", keys)
+		}
+	})
+}
+
+// openTestDB membuka database SQLite sementara dengan tabel yang dipakai package ini
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.StoredFile{}, &models.Product{}, &models.Location{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestFileStore(t *testing.T) {
+	testStoreContract(t, NewFileStore(t.TempDir()))
+}
+
+func TestDatabaseStore(t *testing.T) {
+	testStoreContract(t, NewDatabaseStore(openTestDB(t)))
+}
+
+// TestS3Store berjalan terhadap MinIO/S3 sungguhan jika S3_TEST_ENDPOINT dan S3_TEST_BUCKET diisi,
+// misalnya setelah `docker run -p 9000:9000 minio/minio server /data` dan `mc mb local/test`
+func TestS3Store(t *testing.T) {
+	endpoint, bucket := os.Getenv("S3_TEST_ENDPOINT"), os.Getenv("S3_TEST_BUCKET")
+	if endpoint == "" || bucket == "" {
+		t.Skip("S3_TEST_ENDPOINT and S3_TEST_BUCKET are not set")
+	}
+	useSSL, _ := strconv.ParseBool(os.Getenv("S3_TEST_USE_SSL"))
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  endpoint,
+		Bucket:    bucket,
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		Region:    os.Getenv("S3_TEST_REGION"),
+		// Prefix unik agar test tidak bentrok dengan object lain di bucket
+		Prefix: fmt.Sprintf("filestore-test-%d", time.Now().UnixNano()),
+		UseSSL: useSSL,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	testStoreContract(t, store)
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"time"
 	"warehouse-backend/database"
+	"warehouse-backend/filestore"
 	"warehouse-backend/models"
 	"warehouse-backend/routes"
+	"warehouse-backend/utils"
 
 	_ "warehouse-backend/docs"
 
@@ -20,14 +24,80 @@ import (
 func runMigrations() error {
 	database.Connect()
 	db := database.GetDB()
-	err := db.AutoMigrate(&models.User{}, &models.Product{})
+	err := db.AutoMigrate(
+		&models.User{},
+		&models.Product{},
+		&models.StockMovement{},
+		&models.Warehouse{},
+		&models.WarehouseStock{},
+		&models.Location{},
+		&models.LocationStock{},
+		&models.Transfer{},
+		&models.TransferLine{},
+		&models.Invite{},
+		&models.RegistrationLock{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.SKUSequence{},
+		&models.PrintJob{},
+		&models.ProductBarcode{},
+		&models.StoredFile{},
+	)
 	if err != nil {
 		log.Fatalf("Gagal melakukan migrasi database: %v", err)
 	}
+
+	if err := database.SeedDefaultWarehouse(db); err != nil {
+		log.Fatalf("Gagal menyiapkan gudang default: %v", err)
+	}
+
+	if err := database.EnsureProductSearchIndex(db); err != nil {
+		log.Fatalf("Gagal membuat index pencarian produk: %v", err)
+	}
+
+	if err := database.EnsureAdmin(db); err != nil {
+		log.Fatalf("Gagal menyiapkan admin: %v", err)
+	}
 	fmt.Println("✅ Migrasi database berhasil!")
 	return nil
 }
 
+// runBarcodeReconcile mencocokkan gambar barcode di storage aktif dengan produk dan lokasi:
+// gambar yang hilang dibuat ulang dan gambar yatim dihapus
+func runBarcodeReconcile(args []string) {
+	flags := flag.NewFlagSet("barcodes reconcile", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "hanya laporkan tanpa mengubah storage atau database")
+	flags.Parse(args)
+
+	if err := filestore.Setup(database.DB); err != nil {
+		log.Fatalf("Gagal menyiapkan storage barcode: %v", err)
+	}
+	report, err := filestore.ReconcileBarcodes(context.Background(), database.DB, *dryRun)
+	if err != nil {
+		log.Fatalf("Gagal merekonsiliasi barcode: %v", err)
+	}
+
+	for _, key := range report.Missing {
+		fmt.Println("missing:", key)
+	}
+	for _, key := range report.Orphans {
+		fmt.Println("orphan: ", key)
+	}
+	for _, message := range report.Errors {
+		fmt.Println("error:  ", message)
+	}
+
+	status := "diperbaiki"
+	if *dryRun {
+		status = "perlu diperbaiki (dry run)"
+	}
+	fmt.Printf("%d produk/lokasi diperiksa: %d gambar hilang, %d gambar yatim, %d barcode_path %s\n",
+		report.Checked, len(report.Missing), len(report.Orphans), report.PathsUpdated, status)
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
 // @title Simple Warehouse API
 // @version 1.0
 // @description API untuk mengelola gudang sederhana.
@@ -49,6 +119,30 @@ func main() {
 		return
 	}
 
+	// Rekonsiliasi gambar barcode: barcodes reconcile [--dry-run]
+	if len(os.Args) > 2 && os.Args[1] == "barcodes" && os.Args[2] == "reconcile" {
+		runBarcodeReconcile(os.Args[3:])
+		return
+	}
+
+	// Muat kunci JWT dari konfigurasi
+	if err := utils.LoadSigningKeys(); err != nil {
+		log.Fatalf("Gagal memuat kunci JWT: %v", err)
+	}
+
+	// Backend penyimpanan gambar barcode (filesystem, database atau s3)
+	if err := filestore.Setup(database.DB); err != nil {
+		log.Fatalf("Gagal menyiapkan storage barcode: %v", err)
+	}
+
+	// Pola SKU yang salah lebih baik ketahuan saat start daripada saat membuat produk
+	if err := utils.ValidateSKUPattern(utils.SKUPattern()); err != nil {
+		log.Fatalf("SKU_PATTERN tidak valid: %v", err)
+	}
+	if err := utils.ValidateSKUSymbology(utils.SKUPattern(), utils.DefaultSymbology()); err != nil {
+		log.Fatalf("SKU_PATTERN tidak cocok dengan BARCODE_SYMBOLOGY: %v", err)
+	}
+
 	// Inisialisasi router
 	r := gin.Default()
 
@@ -94,6 +188,12 @@ func main() {
 	// Setup Routes
 	routes.AuthRoutes(r)
 	routes.ProductRoutes(r)
+	routes.WarehouseRoutes(r)
+	routes.LocationRoutes(r)
+	routes.TransferRoutes(r)
+	routes.UserRoutes(r)
+	routes.LabelRoutes(r)
+	routes.ScanRoutes(r)
 
 	// Server run on port 8080
 	log.Println("Server running on port 8080")
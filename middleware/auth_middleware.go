@@ -3,9 +3,21 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
+	"warehouse-backend/database"
+	"warehouse-backend/models"
 	"warehouse-backend/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	userKey     = "user"
+	userIDKey   = "user_id"
+	roleKey     = "role"
+	tokenIDKey  = "token_id"
+	tokenExpKey = "token_exp"
 )
 
 // AuthMiddleware melindungi endpoint dengan JWT
@@ -33,6 +45,78 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		claims, ok := token.Claims.(jwt.MapClaims)
+		jti, _ := claims["jti"].(string)
+		if !ok || jti == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
+
+		// Token yang sudah logout ditolak walaupun belum kedaluwarsa
+		var revoked int64
+		if err := database.DB.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&revoked).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify token"})
+			c.Abort()
+			return
+		}
+		if revoked > 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
+			c.Abort()
+			return
+		}
+
+		// Muat user dari database agar user yang dihapus/dinonaktifkan langsung tertolak
+		// dan perubahan role berlaku tanpa menunggu token baru
+		userID, _ := claims["user_id"].(float64)
+		var user models.User
+		if err := database.DB.First(&user, uint(userID)).Error; err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			c.Abort()
+			return
+		}
+		if !user.IsActive() {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Account disabled"})
+			c.Abort()
+			return
+		}
+
+		c.Set(tokenIDKey, jti)
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			c.Set(tokenExpKey, exp.Time)
+		}
+		c.Set(userKey, &user)
+		c.Set(userIDKey, user.ID)
+		c.Set(roleKey, user.Role)
+
 		c.Next()
 	}
 }
+
+// CurrentUser mengembalikan user yang sedang login, atau nil jika AuthMiddleware tidak dipasang
+func CurrentUser(c *gin.Context) *models.User {
+	if user, ok := c.Get(userKey); ok {
+		return user.(*models.User)
+	}
+	return nil
+}
+
+// CurrentUserID mengembalikan ID user dari token JWT yang sudah divalidasi
+func CurrentUserID(c *gin.Context) uint {
+	return c.GetUint(userIDKey)
+}
+
+// CurrentRole mengembalikan role terkini user yang sedang login
+func CurrentRole(c *gin.Context) string {
+	return c.GetString(roleKey)
+}
+
+// CurrentTokenID mengembalikan jti dari access token yang sedang dipakai
+func CurrentTokenID(c *gin.Context) string {
+	return c.GetString(tokenIDKey)
+}
+
+// CurrentTokenExpiry mengembalikan waktu kedaluwarsa access token yang sedang dipakai
+func CurrentTokenExpiry(c *gin.Context) time.Time {
+	return c.GetTime(tokenExpKey)
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+	"warehouse-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole membatasi endpoint hanya untuk role tertentu. Admin selalu diizinkan.
+// Harus dipasang setelah AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	allowed := map[string]bool{models.RoleAdmin: true}
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *gin.Context) {
+		if !allowed[CurrentRole(c)] {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
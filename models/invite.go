@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Mode registrasi yang diatur lewat REGISTRATION_MODE
+const (
+	RegistrationOpen     = "open"
+	RegistrationInvite   = "invite"
+	RegistrationDisabled = "disabled"
+)
+
+// Invite allows one person to register with a preassigned role. Only the hash
+// of the invite code is stored; the code itself is returned once on creation.
+type Invite struct {
+	gorm.Model
+	Email     string     `gorm:"type:varchar(255);not null;index" json:"email" example:"operator@example.com"`
+	Role      string     `gorm:"type:varchar(20);not null" json:"role" example:"operator"`
+	CodeHash  string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	UsedBy    *uint      `json:"used_by"`
+	CreatedBy uint       `json:"created_by" example:"1"`
+}
+
+// Usable bernilai true jika undangan belum dipakai dan belum kedaluwarsa
+func (i *Invite) Usable(now time.Time) bool {
+	return i.UsedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// InviteSwagger represents an invite payload for Swagger documentation
+type InviteSwagger struct {
+	Email          string `json:"email" example:"operator@example.com"`
+	Role           string `json:"role" example:"operator"`
+	ExpiresInHours int    `json:"expires_in_hours" example:"72"`
+}
+
+// RegistrationLock adalah tabel satu baris yang dikunci selama registrasi agar dua registrasi
+// serentak tidak sama-sama melihat nol user dan menjadi admin
+type RegistrationLock struct {
+	ID        uint `gorm:"primaryKey"`
+	UpdatedAt time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+// LabelItem adalah satu produk yang dicetak labelnya, dipilih lewat ID atau SKU
+type LabelItem struct {
+	ProductID uint   `json:"product_id" example:"1"`
+	SKU       string `json:"sku" example:"SKU-2024-000001"`
+	Copies    int    `json:"copies" example:"3"`
+}
+
+// LabelRequest represents the request body of POST /labels
+type LabelRequest struct {
+	Template  string      `json:"template" example:"avery-l7160"`
+	Symbology string      `json:"symbology" example:"code128"`
+	StartAt   *int        `json:"start_at" example:"1"`
+	Border    bool        `json:"border" example:"false"`
+	Items     []LabelItem `json:"items"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var locationPartPattern = regexp.MustCompile(`^[A-Z0-9]{1,10}$`)
+
+// Location is a storage position inside a warehouse, addressed hierarchically
+// as zone / aisle / rack / shelf / bin. Lower levels are optional but may not
+// be skipped, e.g. a shelf requires an aisle and a rack.
+type Location struct {
+	gorm.Model
+	WarehouseID uint       `gorm:"not null;index" json:"warehouse_id" example:"1"`
+	Code        string     `gorm:"type:varchar(100);uniqueIndex;not null" json:"code" example:"MAIN-A-01-02-03-04"`
+	Zone        string     `gorm:"type:varchar(10);not null" json:"zone" example:"A"`
+	Aisle       string     `gorm:"type:varchar(10)" json:"aisle" example:"01"`
+	Rack        string     `gorm:"type:varchar(10)" json:"rack" example:"02"`
+	Shelf       string     `gorm:"type:varchar(10)" json:"shelf" example:"03"`
+	Bin         string     `gorm:"type:varchar(10)" json:"bin" example:"04"`
+	Description string     `gorm:"type:varchar(255)" json:"description" example:"Rak barang kecil"`
+	BarcodePath string     `json:"barcode_path"`
+	Warehouse   *Warehouse `json:"warehouse,omitempty"`
+}
+
+// LocationStock holds the quantity of one product in one location. The sum over
+// a warehouse's locations may be lower than WarehouseStock; the remainder is
+// stock that has not been put away into a bin yet.
+type LocationStock struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ProductID  uint      `gorm:"not null;uniqueIndex:idx_location_stock_product,priority:1" json:"product_id" example:"1"`
+	LocationID uint      `gorm:"not null;uniqueIndex:idx_location_stock_product,priority:2;index" json:"location_id" example:"1"`
+	Quantity   int       `gorm:"not null;default:0" json:"quantity" example:"25"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Product    *Product  `json:"product,omitempty"`
+	Location   *Location `json:"location,omitempty"`
+}
+
+// LocationSwagger represents a location payload for Swagger documentation
+type LocationSwagger struct {
+	WarehouseID uint   `json:"warehouse_id" example:"1"`
+	Zone        string `json:"zone" example:"A"`
+	Aisle       string `json:"aisle" example:"01"`
+	Rack        string `json:"rack" example:"02"`
+	Shelf       string `json:"shelf" example:"03"`
+	Bin         string `json:"bin" example:"04"`
+	Description string `json:"description" example:"Rak barang kecil"`
+}
+
+// Parts mengembalikan komponen lokasi yang terisi, dari zone sampai bin
+func (l *Location) Parts() []string {
+	parts := []string{}
+	for _, part := range []string{l.Zone, l.Aisle, l.Rack, l.Shelf, l.Bin} {
+		if part == "" {
+			break
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// Normalize menyeragamkan komponen lokasi lalu memvalidasinya
+func (l *Location) Normalize() error {
+	fields := []*string{&l.Zone, &l.Aisle, &l.Rack, &l.Shelf, &l.Bin}
+	names := []string{"zone", "aisle", "rack", "shelf", "bin"}
+
+	gap := ""
+	for i, field := range fields {
+		*field = strings.ToUpper(strings.TrimSpace(*field))
+		if *field == "" {
+			if i == 0 {
+				return errors.New("zone is required")
+			}
+			if gap == "" {
+				gap = names[i]
+			}
+			continue
+		}
+		if gap != "" {
+			return errors.New(names[i] + " requires " + gap)
+		}
+		if !locationPartPattern.MatchString(*field) {
+			return errors.New(names[i] + " must be 1-10 letters or digits")
+		}
+	}
+	return nil
+}
+
+// BuildCode membentuk kode lokasi dari kode gudang dan komponennya
+func (l *Location) BuildCode(warehouseCode string) {
+	l.Code = strings.Join(append([]string{warehouseCode}, l.Parts()...), "-")
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Status print job
+const (
+	PrintJobGenerated = "generated" // ZPL diunduh, tidak dikirim ke printer
+	PrintJobSent      = "sent"
+	PrintJobFailed    = "failed"
+)
+
+// PrintJob records one ZPL label that was generated or sent to a printer. The
+// exact ZPL is stored so a reprint produces the same label even if the
+// product has changed since.
+type PrintJob struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	ProductID   uint      `gorm:"not null;index" json:"product_id" example:"1"`
+	SKU         string    `gorm:"type:varchar(100)" json:"sku" example:"SKU-2024-000001"`
+	Template    string    `gorm:"type:varchar(30)" json:"template" example:"thermal-2x1"`
+	Symbology   string    `gorm:"type:varchar(20)" json:"symbology" example:"code128"`
+	DPI         int       `json:"dpi" example:"203"`
+	Copies      int       `json:"copies" example:"1"`
+	Printer     string    `gorm:"type:varchar(255)" json:"printer" example:"10.0.0.50:9100"`
+	Status      string    `gorm:"type:varchar(20);not null;index" json:"status" example:"sent"`
+	Error       string    `gorm:"type:varchar(255)" json:"error,omitempty"`
+	ZPL         string    `gorm:"type:text" json:"zpl"`
+	ReprintOfID *uint     `gorm:"index" json:"reprint_of_id"`
+	UserID      uint      `gorm:"index" json:"user_id" example:"1"`
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
+
+// ZPLRequest represents the request body of POST /labels/zpl and POST /labels/print
+type ZPLRequest struct {
+	ProductID uint   `json:"product_id" example:"1"`
+	SKU       string `json:"sku" example:"SKU-2024-000001"`
+	Template  string `json:"template" example:"thermal-2x1"`
+	Symbology string `json:"symbology" example:"code128"`
+	DPI       int    `json:"dpi" example:"203"`
+	Copies    int    `json:"copies" example:"1"`
+}
@@ -4,22 +4,71 @@ import (
 	"gorm.io/gorm"
 )
 
-// Product represents a product in the warehouse
+// Kebijakan saat pengurangan stok melebihi jumlah yang tersedia
+const (
+	StockPolicyReject = "reject" // tolak dengan 422
+	StockPolicyAllow  = "allow"  // izinkan stok negatif (backorder)
+	StockPolicyClamp  = "clamp"  // potong menjadi nol
+)
+
+// IsValidStockPolicy checks a negative stock policy value
+func IsValidStockPolicy(policy string) bool {
+	return policy == StockPolicyReject || policy == StockPolicyAllow || policy == StockPolicyClamp
+}
+
+// Product represents a product in the warehouse. An empty NegativeStockPolicy
+// falls back to the global NEGATIVE_STOCK_POLICY setting.
 type Product struct {
-	gorm.Model         // ID, CreatedAt, UpdatedAt, DeletedAt
-	Name        string `gorm:"type:varchar(255);not null" json:"name" example:"Produk A"`
-	SKU         string `gorm:"type:varchar(100);uniqueIndex;not null" json:"sku" example:"SKU123"`
-	Quantity    int    `gorm:"not null" json:"quantity" example:"100"`
-	Location    string `gorm:"type:varchar(255)" json:"location" example:"Rak 1"`
-	Status      string `gorm:"type:varchar(50);not null" json:"status" example:"available"`
-	BarcodePath string `json:"barcode_path"`
+	gorm.Model                           // ID, CreatedAt, UpdatedAt, DeletedAt
+	Name                string           `gorm:"type:varchar(255);not null" json:"name" example:"Produk A"`
+	SKU                 string           `gorm:"type:varchar(100);uniqueIndex;not null" json:"sku" example:"SKU123"`
+	Category            string           `gorm:"type:varchar(50);index" json:"category" example:"ELEC"`
+	Quantity            int              `gorm:"not null" json:"quantity" example:"100"`
+	Location            string           `gorm:"type:varchar(255)" json:"location" example:"Rak 1"`
+	Status              string           `gorm:"type:varchar(50);not null" json:"status" example:"available"`
+	BarcodePath         string           `json:"barcode_path"`
+	Version             uint             `gorm:"not null;default:1" json:"version" example:"1"`
+	NegativeStockPolicy string           `gorm:"type:varchar(20)" json:"negative_stock_policy" example:"reject"`
+	Barcodes            []ProductBarcode `json:"barcodes,omitempty"`
+	// WarehouseID hanya dipakai saat produk dibuat: gudang untuk stok awal dan {WH} di SKU_PATTERN.
+	// 0 berarti gudang default. Tidak disimpan karena stok per gudang ada di WarehouseStock.
+	WarehouseID uint `gorm:"-" json:"warehouse_id,omitempty"`
+}
+
+// UpdateStatus menghitung ulang Status berdasarkan Quantity
+func (p *Product) UpdateStatus() {
+	if p.Quantity < 0 {
+		p.Status = "Backorder"
+	} else if p.Quantity == 0 {
+		p.Status = "Out of Stock"
+	} else if p.Quantity < 10 {
+		p.Status = "Low Stock"
+	} else {
+		p.Status = "Available"
+	}
+}
+
+// ProductUpdate adalah field yang boleh diubah lewat PUT /products/{id}. Field yang tidak dikirim
+// tetap bernilai lama; field lain di body (status, barcode_path, deleted_at, ...) diabaikan.
+type ProductUpdate struct {
+	Name                *string `json:"name" example:"Produk A"`
+	SKU                 *string `json:"sku" example:"ELEC-2024-000001"`
+	Category            *string `json:"category" example:"ELEC"`
+	Quantity            *int    `json:"quantity" example:"100"`
+	Location            *string `json:"location" example:"Rak 1"`
+	NegativeStockPolicy *string `json:"negative_stock_policy" example:"reject"`
+	Version             *uint   `json:"version" example:"3"`
 }
 
 // ProductSwagger represents a product in the warehouse for Swagger documentation
 // @Description Product represents a product in the warehouse
 type ProductSwagger struct {
-	Name     string `json:"name" example:"Produk A"`
-	Quantity int    `json:"quantity" example:"100"`
-	Location string `json:"location" example:"Rak 1"`
-	Status   string `json:"status" example:"available"`
+	Name                string `json:"name" example:"Produk A"`
+	SKU                 string `json:"sku" example:"ELEC-2024-000001"`
+	Category            string `json:"category" example:"ELEC"`
+	Quantity            int    `json:"quantity" example:"100"`
+	Location            string `json:"location" example:"Rak 1"`
+	Status              string `json:"status" example:"available"`
+	NegativeStockPolicy string `json:"negative_stock_policy" example:"reject"`
+	WarehouseID         uint   `json:"warehouse_id" example:"1"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ProductBarcode is an additional code that identifies a product, such as the
+// manufacturer's EAN/UPC or the barcode printed on a case. Scanning it counts
+// as PackQuantity units of the product.
+type ProductBarcode struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	ProductID    uint      `gorm:"not null;index" json:"product_id" example:"1"`
+	Code         string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"code" example:"8991234567890"`
+	Symbology    string    `gorm:"type:varchar(20);not null" json:"symbology" example:"ean13"`
+	GTIN         *string   `gorm:"type:varchar(14);uniqueIndex" json:"gtin,omitempty" example:"08991234567890"` // GTIN-14, hanya untuk symbology GS1
+	PackQuantity int       `gorm:"not null;default:1" json:"pack_quantity" example:"12"`
+	Description  string    `gorm:"type:varchar(100)" json:"description" example:"Karton isi 12"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ProductBarcodeSwagger represents a product barcode payload for Swagger documentation
+type ProductBarcodeSwagger struct {
+	Code         string `json:"code" example:"8991234567890"`
+	Symbology    string `json:"symbology" example:"ean13"`
+	PackQuantity int    `json:"pack_quantity" example:"12"`
+	Description  string `json:"description" example:"Karton isi 12"`
+}
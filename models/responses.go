@@ -14,3 +14,93 @@ type ErrorResponse struct {
 type DeleteProductResponse struct {
 	Message string `json:"message" example:"Product deleted successfully"`
 }
+
+// ProductPage represents one page of GET /products
+type ProductPage struct {
+	Data       []Product `json:"data"`
+	Page       int       `json:"page,omitempty" example:"1"`
+	PageSize   int       `json:"page_size" example:"50"`
+	Total      int64     `json:"total" example:"1234"`
+	NextCursor string    `json:"next_cursor,omitempty" example:"eyJzIjoiLXF1YW50aXR5In0"`
+}
+
+// ProductSearchResult is one product returned by GET /products/search
+type ProductSearchResult struct {
+	Product    Product           `json:"product"`
+	Score      float64           `json:"score" example:"12.5"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// ProductSearchResponse represents the response of GET /products/search
+type ProductSearchResponse struct {
+	Query string                `json:"query" example:"kabel usb"`
+	Total int                   `json:"total" example:"3"`
+	Data  []ProductSearchResult `json:"data"`
+}
+
+// Hasil per baris impor CSV
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+	ImportRejected  = "rejected"
+)
+
+// ImportRowResult is the outcome of one CSV line in POST /products/import
+type ImportRowResult struct {
+	Line      int    `json:"line" example:"2"`
+	Action    string `json:"action" example:"created"`
+	SKU       string `json:"sku,omitempty" example:"SKU-1700000000000"`
+	ProductID uint   `json:"product_id,omitempty" example:"1"`
+	Error     string `json:"error,omitempty" example:"Quantity must be a number"`
+}
+
+// ImportReport represents the response of POST /products/import
+type ImportReport struct {
+	DryRun    bool              `json:"dry_run" example:"false"`
+	Created   int               `json:"created" example:"10"`
+	Updated   int               `json:"updated" example:"3"`
+	Unchanged int               `json:"unchanged" example:"0"`
+	Rejected  int               `json:"rejected" example:"1"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// Add appends a row result and updates the summary counters
+func (r *ImportReport) Add(result ImportRowResult) {
+	switch result.Action {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportUnchanged:
+		r.Unchanged++
+	case ImportRejected:
+		r.Rejected++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+// Status per baris bulk insert
+const (
+	BulkCreated    = "created"
+	BulkFailed     = "failed"
+	BulkRolledBack = "rolled_back"
+	BulkSkipped    = "skipped"
+)
+
+// BulkRowResult is the outcome of one element in POST /products/bulk
+type BulkRowResult struct {
+	Index     int    `json:"index" example:"0"`
+	Status    string `json:"status" example:"created"`
+	SKU       string `json:"sku,omitempty" example:"SKU-1700000000000"`
+	ProductID uint   `json:"product_id,omitempty" example:"1"`
+	Error     string `json:"error,omitempty" example:"Name is required"`
+}
+
+// BulkInsertResponse represents the response of POST /products/bulk
+type BulkInsertResponse struct {
+	Atomic  bool            `json:"atomic" example:"true"`
+	Created int             `json:"created" example:"9"`
+	Failed  int             `json:"failed" example:"1"`
+	Results []BulkRowResult `json:"results"`
+}
@@ -0,0 +1,55 @@
+package models
+
+// Jenis entitas hasil scan
+const (
+	ScanTypeProduct  = "product"
+	ScanTypeLocation = "location"
+)
+
+// Cara kode scan dicocokkan
+const (
+	ScanMatchSKU          = "sku"
+	ScanMatchBarcode      = "barcode"
+	ScanMatchGTIN         = "gtin"
+	ScanMatchLocationCode = "location_code"
+)
+
+// ScanResult represents the entity a scanned code resolved to. Products come
+// with their stock per warehouse, locations with the products stored in them.
+// PackQuantity is the number of units one scan of the code stands for.
+type ScanResult struct {
+	Code         string           `json:"code" example:"SKU-2024-000001"`
+	Type         string           `json:"type" example:"product"`
+	MatchedBy    string           `json:"matched_by" example:"sku"`
+	PackQuantity int              `json:"pack_quantity,omitempty" example:"1"`
+	Barcode      *ProductBarcode  `json:"barcode,omitempty"`
+	Product      *Product         `json:"product,omitempty"`
+	Stock        []WarehouseStock `json:"stock,omitempty"`
+	Location     *Location        `json:"location,omitempty"`
+	Contents     []LocationStock  `json:"contents,omitempty"`
+}
+
+// ScanAdjustRequest represents the request body of POST /scan/{code}. Change
+// counts scans and is multiplied by the pack quantity of the scanned code. The
+// location can be given by ID or by its scanned code.
+type ScanAdjustRequest struct {
+	Change       int    `json:"change" example:"-1"`
+	Reason       string `json:"reason" example:"pick"`
+	Reference    string `json:"reference" example:"SO-2024-0001"`
+	WarehouseID  uint   `json:"warehouse_id" example:"1"`
+	LocationID   uint   `json:"location_id" example:"1"`
+	LocationCode string `json:"location_code" example:"MAIN-A-01-02"`
+}
+
+// ImageScanResult represents one barcode decoded from an uploaded image. Match
+// is nil when the code belongs to no product or location.
+type ImageScanResult struct {
+	Symbology string      `json:"symbology" example:"ean13"`
+	Code      string      `json:"code" example:"4006381333931"`
+	Match     *ScanResult `json:"match"`
+}
+
+// ImageScanResponse represents the response of POST /scan/image
+type ImageScanResponse struct {
+	Barcodes []ImageScanResult `json:"barcodes"`
+}
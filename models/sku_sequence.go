@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SKUSequence menyimpan nomor urut terakhir untuk setiap prefix SKU yang sudah dirender,
+// misalnya "ELEC-2024-#" untuk pola {CAT}-{YYYY}-{SEQ:6}
+type SKUSequence struct {
+	Name      string    `gorm:"primaryKey;type:varchar(150)" json:"name"`
+	Value     uint64    `gorm:"not null;default:0" json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Reason code untuk pergerakan stok
+const (
+	MovementReasonInitial    = "initial"
+	MovementReasonReceipt    = "receipt"
+	MovementReasonPick       = "pick"
+	MovementReasonAdjustment = "adjustment"
+	MovementReasonCount      = "count"
+	MovementReasonReturn     = "return"
+	MovementReasonDamage     = "damage"
+
+	// Hanya ditulis oleh dokumen transfer, tidak bisa dikirim langsung oleh klien
+	MovementReasonTransferOut = "transfer_out"
+	MovementReasonTransferIn  = "transfer_in"
+)
+
+// ErrMovementImmutable dikembalikan jika ada upaya mengubah atau menghapus ledger
+var ErrMovementImmutable = errors.New("stock movements are append-only")
+
+// StockMovement records a single change to a product's quantity. Rows are
+// only ever inserted, never updated or deleted.
+type StockMovement struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	ProductID     uint      `gorm:"not null;index:idx_movements_product_created,priority:1" json:"product_id" example:"1"`
+	WarehouseID   *uint     `gorm:"index" json:"warehouse_id" example:"1"`
+	LocationID    *uint     `gorm:"index" json:"location_id" example:"1"`
+	TransferID    *uint     `gorm:"index" json:"transfer_id" example:"1"`
+	Delta         int       `gorm:"not null" json:"delta" example:"-5"`
+	QuantityAfter int       `gorm:"not null" json:"quantity_after" example:"95"`
+	ReasonCode    string    `gorm:"type:varchar(50);not null" json:"reason_code" example:"pick"`
+	Reference     string    `gorm:"type:varchar(100)" json:"reference" example:"SO-2024-0001"`
+	UserID        uint      `gorm:"index" json:"user_id" example:"1"`
+	CreatedAt     time.Time `gorm:"index:idx_movements_product_created,priority:2" json:"created_at"`
+}
+
+// IsValidMovementReason checks whether a reason code may be supplied by clients
+func IsValidMovementReason(reason string) bool {
+	switch reason {
+	case MovementReasonReceipt, MovementReasonPick, MovementReasonAdjustment,
+		MovementReasonCount, MovementReasonReturn, MovementReasonDamage:
+		return true
+	}
+	return false
+}
+
+// BeforeUpdate menolak perubahan pada ledger
+func (m *StockMovement) BeforeUpdate(tx *gorm.DB) error {
+	return ErrMovementImmutable
+}
+
+// BeforeDelete menolak penghapusan ledger
+func (m *StockMovement) BeforeDelete(tx *gorm.DB) error {
+	return ErrMovementImmutable
+}
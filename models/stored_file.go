@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// StoredFile holds a file kept in the database when BARCODE_STORAGE=database.
+// Path is the storage key, e.g. "barcodes/SKU-1.png".
+type StoredFile struct {
+	Path        string    `gorm:"primaryKey;type:varchar(255)" json:"path"`
+	ContentType string    `gorm:"type:varchar(100)" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	Data        []byte    `gorm:"type:longblob;not null" json:"-"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// RefreshToken is a long-lived token exchanged for new access tokens. Tokens
+// rotate on every use; all tokens descending from one login share a FamilyID
+// so the whole chain can be revoked when reuse of an old token is detected.
+type RefreshToken struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	FamilyID     string     `gorm:"type:varchar(64);not null;index" json:"family_id"`
+	TokenHash    string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ReplacedByID *uint      `json:"replaced_by_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// RevokedToken records an access token (by jti) that must no longer be accepted
+// even though it has not expired yet.
+type RevokedToken struct {
+	ID        uint      `gorm:"primarykey"`
+	JTI       string    `gorm:"column:jti;type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// RefreshRequest is the payload for refreshing or revoking a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" example:"6b1f0c..."`
+}
+
+// LogoutRequest is the payload for logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"6b1f0c..."`
+	All          bool   `json:"all" example:"false"`
+}
+
+// TokenResponse is returned on login and refresh
+type TokenResponse struct {
+	Token        string `json:"token" example:"eyJhbGciOi..."`
+	RefreshToken string `json:"refresh_token" example:"6b1f0c..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"900"`
+}
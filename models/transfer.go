@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status dokumen transfer
+const (
+	TransferStatusDraft     = "draft"
+	TransferStatusInTransit = "in_transit"
+	TransferStatusReceived  = "received"
+)
+
+// Transfer moves stock from a source warehouse (and optionally a bin) to a
+// destination. Dispatch takes the stock out of the source and receive puts it
+// into the destination; both write movements linked by TransferID.
+type Transfer struct {
+	gorm.Model
+	Number                 string         `gorm:"type:varchar(30);uniqueIndex" json:"number" example:"TRF-000001"`
+	SourceWarehouseID      uint           `gorm:"not null;index" json:"source_warehouse_id" example:"1"`
+	SourceLocationID       *uint          `json:"source_location_id" example:"1"`
+	DestinationWarehouseID uint           `gorm:"not null;index" json:"destination_warehouse_id" example:"2"`
+	DestinationLocationID  *uint          `json:"destination_location_id" example:"5"`
+	Status                 string         `gorm:"type:varchar(20);not null;index" json:"status" example:"draft"`
+	Note                   string         `gorm:"type:varchar(255)" json:"note" example:"Restock cabang"`
+	CreatedBy              uint           `json:"created_by" example:"1"`
+	DispatchedBy           *uint          `json:"dispatched_by"`
+	DispatchedAt           *time.Time     `json:"dispatched_at"`
+	ReceivedBy             *uint          `json:"received_by"`
+	ReceivedAt             *time.Time     `json:"received_at"`
+	Lines                  []TransferLine `json:"lines"`
+}
+
+// TransferLine is one product and quantity on a transfer
+type TransferLine struct {
+	ID         uint     `gorm:"primarykey" json:"id"`
+	TransferID uint     `gorm:"not null;index" json:"transfer_id"`
+	ProductID  uint     `gorm:"not null" json:"product_id" example:"1"`
+	Quantity   int      `gorm:"not null" json:"quantity" example:"10"`
+	Product    *Product `json:"product,omitempty"`
+}
+
+// TransferSwagger represents a transfer payload for Swagger documentation
+type TransferSwagger struct {
+	SourceWarehouseID      uint                  `json:"source_warehouse_id" example:"1"`
+	SourceLocationID       *uint                 `json:"source_location_id" example:"1"`
+	DestinationWarehouseID uint                  `json:"destination_warehouse_id" example:"2"`
+	DestinationLocationID  *uint                 `json:"destination_location_id" example:"5"`
+	Note                   string                `json:"note" example:"Restock cabang"`
+	Lines                  []TransferLineSwagger `json:"lines"`
+}
+
+// TransferLineSwagger represents a transfer line payload for Swagger documentation
+type TransferLineSwagger struct {
+	ProductID uint `json:"product_id" example:"1"`
+	Quantity  int  `json:"quantity" example:"10"`
+}
@@ -1,21 +1,88 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Role user, dari yang paling tinggi ke paling rendah
+const (
+	RoleAdmin    = "admin"
+	RoleManager  = "manager"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+)
+
+// IsValidRole checks whether role is one of the known roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleManager, RoleOperator, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Permission yang dimiliki setiap role. Role yang lebih tinggi mewarisi permission role di bawahnya.
+var rolePermissions = map[string][]string{
+	RoleViewer:   {"products:read", "warehouses:read", "locations:read", "transfers:read"},
+	RoleOperator: {"stock:adjust", "transfers:write"},
+	RoleManager:  {"products:write", "warehouses:write", "locations:write"},
+	RoleAdmin:    {"users:manage", "invites:manage"},
+}
+
+var roleOrder = []string{RoleViewer, RoleOperator, RoleManager, RoleAdmin}
+
+// PermissionsFor returns every permission granted to role, including inherited ones
+func PermissionsFor(role string) []string {
+	permissions := []string{}
+	for _, r := range roleOrder {
+		permissions = append(permissions, rolePermissions[r]...)
+		if r == role {
+			return permissions
+		}
+	}
+	return []string{}
+}
 
 type User struct {
 	gorm.Model
-	Name     string `json:"name"`
-	Email    string `json:"email" gorm:"unique"`
-	Password string `json:"-"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email" gorm:"unique"`
+	Password   string     `json:"-"`
+	Role       string     `json:"role" gorm:"type:varchar(20);not null;default:viewer" example:"viewer"`
+	DisabledAt *time.Time `json:"disabled_at"`
+}
+
+// IsActive reports whether the user is allowed to log in and use tokens
+func (u *User) IsActive() bool {
+	return u.DisabledAt == nil
 }
 
 type UserSwagger struct {
-	Name     string `json:"name" example:"John Doe"`
-	Email    string `json:"email" gorm:"unique" example:"mail@example.com"`
-	Password string `json:"password" example:"password123"`
+	Name       string `json:"name" example:"John Doe"`
+	Email      string `json:"email" gorm:"unique" example:"mail@example.com"`
+	Password   string `json:"password" example:"password123"`
+	InviteCode string `json:"invite_code" example:"3f9c2a..."`
 }
 
 type LoginCredentials struct {
 	Email    string `json:"email" example:"admin1@email.com"`
 	Password string `json:"password" example:"bismillah"`
 }
+
+// UserStatusUpdate is the payload for enabling or disabling a user
+type UserStatusUpdate struct {
+	Active *bool `json:"active" example:"false"`
+}
+
+// Profile is the response of GET /auth/me
+type Profile struct {
+	User        User     `json:"user"`
+	Permissions []string `json:"permissions" example:"products:read,stock:adjust"`
+}
+
+// RoleUpdate is the payload for assigning a role to a user
+type RoleUpdate struct {
+	Role string `json:"role" example:"operator"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultWarehouseCode adalah gudang yang dipakai jika request tidak menyebut gudang
+const DefaultWarehouseCode = "MAIN"
+
+// warehouseCodePattern tidak mengizinkan "-" karena kode lokasi dibentuk dari kode gudang dan komponen
+// lokasi yang digabung dengan "-", dan kode lokasi juga dipakai sebagai nama file barcode dan segmen URL
+var warehouseCodePattern = regexp.MustCompile(`^[A-Z0-9]{1,20}$`)
+
+// Warehouse represents a physical site that holds stock
+type Warehouse struct {
+	gorm.Model
+	Code    string `gorm:"type:varchar(20);uniqueIndex;not null" json:"code" example:"MAIN"`
+	Name    string `gorm:"type:varchar(255);not null" json:"name" example:"Gudang Utama"`
+	Address string `gorm:"type:varchar(500)" json:"address" example:"Jl. Industri No. 1"`
+}
+
+// Normalize menyeragamkan kode dan nama gudang lalu memvalidasinya
+func (w *Warehouse) Normalize() error {
+	w.Code = strings.ToUpper(strings.TrimSpace(w.Code))
+	w.Name = strings.TrimSpace(w.Name)
+	if w.Code == "" || w.Name == "" {
+		return errors.New("Code and name are required")
+	}
+	if !warehouseCodePattern.MatchString(w.Code) {
+		return errors.New("Code must be 1-20 letters or digits")
+	}
+	return nil
+}
+
+// WarehouseStock holds the quantity of one product in one warehouse.
+// Product.Quantity is kept equal to the sum over all warehouses.
+type WarehouseStock struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	ProductID   uint       `gorm:"not null;uniqueIndex:idx_warehouse_stock_product,priority:1" json:"product_id" example:"1"`
+	WarehouseID uint       `gorm:"not null;uniqueIndex:idx_warehouse_stock_product,priority:2;index" json:"warehouse_id" example:"1"`
+	Quantity    int        `gorm:"not null;default:0" json:"quantity" example:"100"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Product     *Product   `json:"product,omitempty"`
+	Warehouse   *Warehouse `json:"warehouse,omitempty"`
+}
+
+// WarehouseSwagger represents a warehouse payload for Swagger documentation
+type WarehouseSwagger struct {
+	Code    string `json:"code" example:"MAIN"`
+	Name    string `json:"name" example:"Gudang Utama"`
+	Address string `json:"address" example:"Jl. Industri No. 1"`
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,7 +11,13 @@ import (
 func AuthRoutes(r *gin.Engine) {
 	auth := r.Group("/api/auth")
 	{
+		auth.GET("/registration", controllers.GetRegistrationMode)
 		auth.POST("/register", controllers.RegisterUser)
 		auth.POST("/login", controllers.LoginUser)
+		auth.POST("/refresh", controllers.RefreshToken)
+		auth.POST("/logout", middleware.AuthMiddleware(), controllers.LogoutUser)
+		auth.GET("/me", middleware.AuthMiddleware(), controllers.GetMe)
 	}
+
+	r.GET("/.well-known/jwks.json", controllers.GetJWKS)
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LabelRoutes(r *gin.Engine) {
+	labelGroup := r.Group("/api/labels")
+	labelGroup.Use(middleware.AuthMiddleware())
+	{
+		labelGroup.POST("/", controllers.PrintLabels)
+		labelGroup.GET("/templates", controllers.GetLabelTemplates)
+
+		labelGroup.POST("/zpl", requireOperator, controllers.GenerateZPL)
+		labelGroup.POST("/print", requireOperator, controllers.PrintZPL)
+		labelGroup.GET("/jobs", controllers.GetPrintJobs)
+		labelGroup.GET("/jobs/:id", controllers.GetPrintJobByID)
+		labelGroup.POST("/jobs/:id/reprint", requireOperator, controllers.ReprintJob)
+	}
+}
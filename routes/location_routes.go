@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LocationRoutes(r *gin.Engine) {
+	locationGroup := r.Group("/api/locations")
+	locationGroup.Use(middleware.AuthMiddleware())
+	{
+		locationGroup.POST("/", requireManager, controllers.CreateLocation)
+		locationGroup.GET("/", controllers.GetLocations)
+
+		locationGroup.GET("/:id", controllers.GetLocationByID)
+		locationGroup.PUT("/:id", requireManager, controllers.UpdateLocation)
+		locationGroup.DELETE("/:id", requireManager, controllers.DeleteLocation)
+
+		locationGroup.GET("/:id/barcode", controllers.GetLocationBarcode)
+		locationGroup.GET("/:id/stock", controllers.GetLocationStock)
+	}
+}
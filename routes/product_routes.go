@@ -11,17 +11,25 @@ func ProductRoutes(r *gin.Engine) {
 	productGroup := r.Group("/api/products")
 	productGroup.Use(middleware.AuthMiddleware())
 	{
-		productGroup.POST("/", controllers.CreateProduct)
+		productGroup.POST("/", requireManager, controllers.CreateProduct)
 		productGroup.GET("/", controllers.GetProducts)
+		productGroup.GET("/search", controllers.SearchProducts)
 
 		productGroup.GET("/:id", controllers.GetProductByID)
-		productGroup.PUT("/:id", controllers.UpdateProduct)
-		productGroup.PUT("/:id/stock", controllers.UpdateStock)
-		productGroup.DELETE("/:id", controllers.DeleteProduct)
+		productGroup.PUT("/:id", requireManager, controllers.UpdateProduct)
+		productGroup.GET("/:id/stock", controllers.GetProductStock)
+		productGroup.PUT("/:id/stock", requireOperator, controllers.UpdateStock)
+		productGroup.GET("/:id/movements", controllers.GetProductMovements)
+		productGroup.DELETE("/:id", requireManager, controllers.DeleteProduct)
+		productGroup.GET("/:id/barcodes", controllers.GetProductBarcodes)
+		productGroup.POST("/:id/barcodes", requireManager, controllers.AddProductBarcode)
+		productGroup.PUT("/:id/barcodes/:barcodeId", requireManager, controllers.UpdateProductBarcode)
+		productGroup.DELETE("/:id/barcodes/:barcodeId", requireManager, controllers.DeleteProductBarcode)
 
 		productGroup.GET("/barcode/:sku", controllers.GetBarcode)
 		productGroup.GET("/export", controllers.ExportProductsCSV)
+		productGroup.POST("/import", requireManager, controllers.ImportProductsCSV)
 		productGroup.GET("/dashboard", controllers.GetStockDashboard)
-		productGroup.POST("/bulk", controllers.BulkInsertProducts)
+		productGroup.POST("/bulk", requireManager, controllers.BulkInsertProducts)
 	}
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"warehouse-backend/middleware"
+	"warehouse-backend/models"
+)
+
+// Guard role yang dipakai di semua grup rute. Semua user yang login boleh membaca;
+// admin selalu lolos RequireRole.
+var (
+	requireAdmin    = middleware.RequireRole()
+	requireManager  = middleware.RequireRole(models.RoleManager)
+	requireOperator = middleware.RequireRole(models.RoleManager, models.RoleOperator)
+)
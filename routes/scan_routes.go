@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ScanRoutes(r *gin.Engine) {
+	scanGroup := r.Group("/api/scan")
+	scanGroup.Use(middleware.AuthMiddleware())
+	{
+		scanGroup.GET("/:code", controllers.ScanCode)
+		scanGroup.POST("/image", controllers.ScanImage)
+		scanGroup.POST("/:code", requireOperator, controllers.ScanAndAdjust)
+	}
+}
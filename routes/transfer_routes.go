@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TransferRoutes(r *gin.Engine) {
+	transferGroup := r.Group("/api/transfers")
+	transferGroup.Use(middleware.AuthMiddleware())
+	{
+		transferGroup.POST("/", requireOperator, controllers.CreateTransfer)
+		transferGroup.GET("/", controllers.GetTransfers)
+
+		transferGroup.GET("/:id", controllers.GetTransferByID)
+		transferGroup.DELETE("/:id", requireOperator, controllers.DeleteTransfer)
+		transferGroup.GET("/:id/movements", controllers.GetTransferMovements)
+		transferGroup.POST("/:id/dispatch", requireOperator, controllers.DispatchTransfer)
+		transferGroup.POST("/:id/receive", requireOperator, controllers.ReceiveTransfer)
+	}
+}
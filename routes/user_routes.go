@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func UserRoutes(r *gin.Engine) {
+	userGroup := r.Group("/api/users")
+	userGroup.Use(middleware.AuthMiddleware(), requireAdmin)
+	{
+		userGroup.GET("/", controllers.GetUsers)
+		userGroup.PUT("/:id/role", controllers.UpdateUserRole)
+		userGroup.PUT("/:id/status", controllers.UpdateUserStatus)
+		userGroup.DELETE("/:id", controllers.DeleteUser)
+	}
+
+	inviteGroup := r.Group("/api/invites")
+	inviteGroup.Use(middleware.AuthMiddleware(), requireAdmin)
+	{
+		inviteGroup.POST("/", controllers.CreateInvite)
+		inviteGroup.GET("/", controllers.GetInvites)
+		inviteGroup.DELETE("/:id", controllers.DeleteInvite)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"warehouse-backend/controllers"
+	"warehouse-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WarehouseRoutes(r *gin.Engine) {
+	warehouseGroup := r.Group("/api/warehouses")
+	warehouseGroup.Use(middleware.AuthMiddleware())
+	{
+		warehouseGroup.POST("/", requireManager, controllers.CreateWarehouse)
+		warehouseGroup.GET("/", controllers.GetWarehouses)
+
+		warehouseGroup.GET("/:id", controllers.GetWarehouseByID)
+		warehouseGroup.PUT("/:id", requireManager, controllers.UpdateWarehouse)
+		warehouseGroup.DELETE("/:id", requireManager, controllers.DeleteWarehouse)
+
+		warehouseGroup.GET("/:id/stock", controllers.GetWarehouseStock)
+		warehouseGroup.PUT("/:id/stock/:product_id", requireOperator, controllers.UpdateWarehouseStock)
+	}
+}
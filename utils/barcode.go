@@ -1,67 +1,331 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"image"
+	"image/color"
 	"image/png"
-	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/boombuler/barcode"
 	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/code39"
+	"github.com/boombuler/barcode/datamatrix"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
 	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
 )
 
-// GenerateBarcode membuat barcode dari SKU dan menyimpannya sebagai file PNG
-func GenerateBarcode(sku string) (string, error) {
-	barcodePath := "storage/barcodes/"
-	os.MkdirAll(barcodePath, os.ModePerm)
+// Symbology barcode yang didukung
+const (
+	SymbologyCode128    = "code128"
+	SymbologyEAN13      = "ean13"
+	SymbologyUPCA       = "upca"
+	SymbologyQR         = "qr"
+	SymbologyDataMatrix = "datamatrix"
+	SymbologyCode39     = "code39"
+)
 
-	// Buat barcode dari SKU
-	barcodeData, err := code128.Encode(sku)
-	if err != nil {
-		return "", err
+// IsValidSymbology checks whether symbology is one of the supported barcode types
+func IsValidSymbology(symbology string) bool {
+	switch symbology {
+	case SymbologyCode128, SymbologyEAN13, SymbologyUPCA, SymbologyQR, SymbologyDataMatrix, SymbologyCode39:
+		return true
 	}
+	return false
+}
 
-	// Get the original bounds
-	bounds := barcodeData.Bounds()
+// DefaultSymbology membaca BARCODE_SYMBOLOGY untuk barcode produk dan lokasi (default code128)
+func DefaultSymbology() string {
+	return GetEnv("BARCODE_SYMBOLOGY", SymbologyCode128)
+}
 
-	// Create a new RGBA image with desired dimensions
-	newImage := image.NewRGBA(image.Rect(0, 0, 300, 150))
+// BarcodeOptions mengatur bentuk gambar barcode. Semua ukuran dalam pixel kecuali QuietZone
+// yang dihitung dalam modul (lebar bar tersempit).
+type BarcodeOptions struct {
+	Symbology   string
+	ModuleWidth int  // pixel per modul, selalu bilangan bulat agar bar tetap tajam
+	BarHeight   int  // tinggi bar untuk barcode linear
+	QuietZone   int  // margin kosong di kiri/kanan (linear) atau sekeliling (2D)
+	ShowText    bool // tulis isi barcode di bawah barcode linear
+	Width       int  // lebar kanvas yang diminta; 0 berarti mengikuti ukuran barcode
+	Height      int  // tinggi kanvas yang diminta; 0 berarti mengikuti ukuran barcode
+}
 
-	// Scale the image using draw.ApproxBiLinear
-	draw.ApproxBiLinear.Scale(newImage, newImage.Bounds(), barcodeData, bounds, draw.Over, nil)
+// DefaultBarcodeOptions mengembalikan ukuran standar untuk symbology tertentu
+func DefaultBarcodeOptions(symbology string) BarcodeOptions {
+	switch symbology {
+	case SymbologyQR, SymbologyDataMatrix:
+		return BarcodeOptions{Symbology: symbology, ModuleWidth: 6, QuietZone: 4}
+	default:
+		return BarcodeOptions{Symbology: symbology, ModuleWidth: 2, BarHeight: 100, QuietZone: 10, ShowText: true}
+	}
+}
 
-	// Path file barcode
-	filePath := filepath.Join(barcodePath, sku+".png")
+// ModuleWidthForDPI menghitung lebar modul dalam pixel untuk printer dengan resolusi dpi,
+// berdasarkan X-dimension 0,33 mm (ukuran nominal EAN/UPC)
+func ModuleWidthForDPI(dpi int) int {
+	return max(1, int(float64(dpi)*0.33/25.4+0.5))
+}
+
+// EncodeBarcode meng-encode content dengan symbology yang diminta dan mengembalikan
+// teks yang dicetak di bawah barcode. EAN-13 dan UPC-A menerima kode dengan atau tanpa
+// check digit; check digit yang salah ditolak.
+func EncodeBarcode(content, symbology string) (barcode.Barcode, string, error) {
+	switch symbology {
+	case SymbologyCode128:
+		code, err := code128.Encode(content)
+		return code, content, err
+	case SymbologyCode39:
+		code, err := code39.Encode(content, false, true)
+		return code, content, err
+	case SymbologyEAN13:
+		gtin, err := CompleteGTIN(content, 13)
+		if err != nil {
+			return nil, "", err
+		}
+		code, err := ean.Encode(gtin)
+		return code, gtin, err
+	case SymbologyUPCA:
+		// UPC-A adalah EAN-13 dengan digit awal 0
+		gtin, err := CompleteGTIN(content, 12)
+		if err != nil {
+			return nil, "", err
+		}
+		code, err := ean.Encode("0" + gtin)
+		return code, gtin, err
+	case SymbologyQR:
+		code, err := qr.Encode(content, qr.M, qr.Auto)
+		return code, "", err
+	case SymbologyDataMatrix:
+		code, err := datamatrix.Encode(content)
+		return code, "", err
+	default:
+		return nil, "", fmt.Errorf("unsupported symbology %q", symbology)
+	}
+}
+
+// barcodeLayout adalah posisi pixel setiap bagian barcode, dipakai bersama oleh PNG dan SVG
+type barcodeLayout struct {
+	code          barcode.Barcode
+	text          string
+	twoD          bool
+	cols, rows    int
+	module        int
+	left, top     int // posisi modul pertama
+	barHeight     int
+	width, height int
+	textTop       int
+	textScale     int
+}
+
+// dark reports whether module (x, y) is a bar
+func (l barcodeLayout) dark(x, y int) bool {
+	bounds := l.code.Bounds()
+	return isDark(l.code.At(bounds.Min.X+x, bounds.Min.Y+y))
+}
 
-	// Simpan barcode sebagai PNG
-	file, err := os.Create(filePath)
+// layoutBarcode meng-encode content lalu menghitung ukuran kanvas. Jika Width/Height diisi,
+// lebar modul dipilih sebesar mungkin (bilangan bulat) yang muat dan sisanya menjadi margin.
+func layoutBarcode(content string, opts BarcodeOptions) (barcodeLayout, error) {
+	code, text, err := EncodeBarcode(content, opts.Symbology)
 	if err != nil {
-		return "", err
+		return barcodeLayout{}, err
+	}
+
+	l := barcodeLayout{
+		code: code,
+		twoD: code.Metadata().Dimensions == 2,
+		cols: code.Bounds().Dx(),
+		rows: code.Bounds().Dy(),
+	}
+	if opts.ShowText && !l.twoD {
+		l.text = text
+	}
+
+	l.module = opts.ModuleWidth
+	if opts.Width > 0 {
+		l.module = opts.Width / (l.cols + 2*opts.QuietZone)
+	}
+	if l.twoD && opts.Height > 0 {
+		l.module = min(l.module, opts.Height/(l.rows+2*opts.QuietZone))
+	}
+	if l.module < 1 {
+		return barcodeLayout{}, errors.New("requested size is too small for this barcode")
+	}
+
+	quiet := opts.QuietZone * l.module
+	naturalWidth := l.cols*l.module + 2*quiet
+	l.width = max(naturalWidth, opts.Width)
+	l.left = quiet + (l.width-naturalWidth)/2
+
+	if l.twoD {
+		naturalHeight := l.rows*l.module + 2*quiet
+		l.height = max(naturalHeight, opts.Height)
+		l.top = quiet + (l.height-naturalHeight)/2
+		return l, nil
+	}
+
+	// Teks basicfont 7x13 diperbesar dengan faktor bulat yang sama dengan modul (maks 3)
+	l.textScale = min(l.module, 3)
+	textHeight := 0
+	if l.text != "" {
+		textHeight = (basicfont.Face7x13.Height + 4) * l.textScale
+	}
+
+	margin := l.module * 4
+	l.barHeight = opts.BarHeight
+	if opts.Height > 0 {
+		l.barHeight = opts.Height - 2*margin - textHeight
 	}
-	defer file.Close()
+	if l.barHeight < 1 {
+		if opts.Height > 0 {
+			return barcodeLayout{}, errors.New("requested height is too small for this barcode")
+		}
+		l.barHeight = 100
+	}
+
+	l.top = margin
+	l.textTop = margin + l.barHeight + 2*l.textScale
+	l.height = margin + l.barHeight + textHeight + margin
+	return l, nil
+}
 
-	err = png.Encode(file, newImage)
+// RenderBarcode menggambar barcode dengan skala modul bilangan bulat (tanpa interpolasi),
+// quiet zone dan teks yang bisa dibaca manusia di bawah barcode linear
+func RenderBarcode(content string, opts BarcodeOptions) (image.Image, error) {
+	l, err := layoutBarcode(content, opts)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	img := newWhiteImage(image.Rect(0, 0, l.width, l.height))
+	if l.twoD {
+		for y := 0; y < l.rows; y++ {
+			for x := 0; x < l.cols; x++ {
+				if l.dark(x, y) {
+					fillRect(img, l.left+x*l.module, l.top+y*l.module, l.module, l.module)
+				}
+			}
+		}
+		return img, nil
 	}
 
-	return filePath, nil
+	for x := 0; x < l.cols; x++ {
+		if l.dark(x, 0) {
+			fillRect(img, l.left+x*l.module, l.top, l.module, l.barHeight)
+		}
+	}
+	if l.text != "" {
+		drawText(img, l.text, l.width/2, l.textTop, l.textScale)
+	}
+	return img, nil
 }
 
-// DeleteBarcode removes the barcode image file for a given SKU
-func DeleteBarcode(sku string) error {
-	barcodePath := filepath.Join("storage/barcodes", sku+".png")
+// RenderBarcodeSVG menghasilkan barcode sebagai SVG. Bar yang berdampingan digabung menjadi
+// satu <rect>. Jika dpi > 0, ukuran SVG ditulis dalam milimeter agar tercetak dengan ukuran fisik yang tepat.
+func RenderBarcodeSVG(content string, opts BarcodeOptions, dpi int) ([]byte, error) {
+	l, err := layoutBarcode(content, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	// Check if file exists before attempting to delete
-	if _, err := os.Stat(barcodePath); os.IsNotExist(err) {
-		return nil // Return nil if file doesn't exist
+	width, height := strconv.Itoa(l.width), strconv.Itoa(l.height)
+	if dpi > 0 {
+		width = strconv.FormatFloat(float64(l.width)/float64(dpi)*25.4, 'f', 2, 64) + "mm"
+		height = strconv.FormatFloat(float64(l.height)/float64(dpi)*25.4, 'f', 2, 64) + "mm"
 	}
 
-	// Delete the barcode file
-	err := os.Remove(barcodePath)
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		width, height, l.width, l.height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><g fill="#000">`, l.width, l.height)
+
+	rows, rowHeight := l.rows, l.module
+	if !l.twoD {
+		rows, rowHeight = 1, l.barHeight
+	}
+	for y := 0; y < rows; y++ {
+		for x := 0; x < l.cols; {
+			if !l.dark(x, y) {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < l.cols && l.dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`,
+				l.left+x*l.module, l.top+y*l.module, run*l.module, rowHeight)
+			x += run
+		}
+	}
+	b.WriteString(`</g>`)
+
+	if l.text != "" {
+		fontSize := basicfont.Face7x13.Height * l.textScale
+		b.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="monospace" font-size="%d" text-anchor="middle" dominant-baseline="hanging">`,
+			l.width/2, l.textTop, fontSize))
+		xml.EscapeText(&b, []byte(l.text))
+		b.WriteString(`</text>`)
+	}
+	b.WriteString(`</svg>`)
+	return b.Bytes(), nil
+}
+
+// BarcodePNG merender barcode sebagai file PNG. Penyimpanannya diatur oleh package filestore.
+func BarcodePNG(content string, opts BarcodeOptions) ([]byte, error) {
+	img, err := RenderBarcode(content, opts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newWhiteImage(rect image.Rectangle) *image.Gray {
+	img := image.NewGray(rect)
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return img
+}
+
+func fillRect(img *image.Gray, x, y, w, h int) {
+	draw.Draw(img, image.Rect(x, y, x+w, y+h), image.Black, image.Point{}, draw.Src)
+}
+
+func isDark(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < 128
+}
+
+// drawText menulis text di tengah centerX dengan font bitmap yang diperbesar scale kali
+func drawText(dst *image.Gray, text string, centerX, top, scale int) {
+	face := basicfont.Face7x13
+	text = strings.ToValidUTF8(text, "?")
+	width := font.MeasureString(face, text).Ceil()
+
+	small := newWhiteImage(image.Rect(0, 0, width, face.Height))
+	drawer := font.Drawer{
+		Dst:  small,
+		Src:  image.Black,
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
 	}
+	drawer.DrawString(text)
 
-	return nil
+	x := centerX - width*scale/2
+	target := image.Rect(x, top, x+width*scale, top+face.Height*scale)
+	draw.NearestNeighbor.Scale(dst, target, small, small.Bounds(), draw.Src, nil)
 }
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/datamatrix"
+	multiqr "github.com/makiuchi-d/gozxing/multi/qrcode"
+	"github.com/makiuchi-d/gozxing/oned"
+	"golang.org/x/image/draw"
+)
+
+// Batas gambar yang didekode: foto ponsel dikecilkan dulu agar dekode tetap cepat. Gambar
+// didekode penuh ke memori (4 byte per piksel) sebelum dikecilkan, jadi 16 MP sudah sekitar 64 MB.
+const (
+	maxScanImagePixels = 16_000_000
+	maxScanImageSide   = 2400
+)
+
+var (
+	// ErrImageFormat dikembalikan jika file bukan gambar JPEG atau PNG
+	ErrImageFormat = errors.New("image must be a JPEG or PNG")
+	// ErrImageTooLarge dikembalikan jika resolusi gambar melebihi batas
+	ErrImageTooLarge = errors.New("image resolution is too large")
+)
+
+// DecodedBarcode is a barcode found in an image
+type DecodedBarcode struct {
+	Symbology string
+	Text      string
+}
+
+// zxingSymbology memetakan format gozxing ke nama symbology yang dipakai aplikasi
+var zxingSymbology = map[gozxing.BarcodeFormat]string{
+	gozxing.BarcodeFormat_CODE_128:    SymbologyCode128,
+	gozxing.BarcodeFormat_CODE_39:     SymbologyCode39,
+	gozxing.BarcodeFormat_EAN_8:       SymbologyEAN8,
+	gozxing.BarcodeFormat_EAN_13:      SymbologyEAN13,
+	gozxing.BarcodeFormat_UPC_A:       SymbologyUPCA,
+	gozxing.BarcodeFormat_UPC_E:       "upce",
+	gozxing.BarcodeFormat_ITF:         "itf",
+	gozxing.BarcodeFormat_QR_CODE:     SymbologyQR,
+	gozxing.BarcodeFormat_DATA_MATRIX: SymbologyDataMatrix,
+}
+
+// ReadBarcodeImage membaca gambar JPEG/PNG lalu mendekode semua barcode di dalamnya.
+// Ukuran gambar diperiksa dari header sebelum didekode penuh.
+func ReadBarcodeImage(r io.ReadSeeker) ([]DecodedBarcode, error) {
+	config, format, err := image.DecodeConfig(r)
+	if err != nil || (format != "jpeg" && format != "png") {
+		return nil, ErrImageFormat
+	}
+	if config.Width*config.Height > maxScanImagePixels {
+		return nil, ErrImageTooLarge
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, ErrImageFormat
+	}
+	return DecodeBarcodes(img)
+}
+
+// DecodeBarcodes mencari barcode Code 128, Code 39, EAN/UPC, ITF, QR dan Data Matrix di dalam gambar.
+// Beberapa QR code bisa ditemukan sekaligus; untuk symbology lain paling banyak satu per jenis.
+// Barcode 1D juga dicari dalam posisi diputar 90 derajat. Slice kosong berarti tidak ada yang terbaca.
+func DecodeBarcodes(img image.Image) ([]DecodedBarcode, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(shrinkImage(img))
+	if err != nil {
+		return nil, err
+	}
+	hints := map[gozxing.DecodeHintType]interface{}{gozxing.DecodeHintType_TRY_HARDER: true}
+
+	found := []DecodedBarcode{}
+	seen := map[string]bool{}
+	add := func(result *gozxing.Result) {
+		text := result.GetText()
+		if text == "" || seen[text] {
+			return
+		}
+		seen[text] = true
+		found = append(found, DecodedBarcode{Symbology: decodedSymbology(result), Text: text})
+	}
+
+	// Error dari reader berarti barcode jenis itu tidak ditemukan atau rusak
+	if results, err := multiqr.NewQRCodeMultiReader().DecodeMultiple(bitmap, hints); err == nil {
+		for _, result := range results {
+			add(result)
+		}
+	}
+	readers := []gozxing.Reader{
+		datamatrix.NewDataMatrixReader(),
+		oned.NewCode128Reader(),
+		oned.NewMultiFormatUPCEANReader(nil),
+		oned.NewCode39Reader(),
+		oned.NewITFReader(),
+	}
+	for _, reader := range readers {
+		if result, err := reader.Decode(bitmap, hints); err == nil {
+			add(result)
+		}
+	}
+	return found, nil
+}
+
+// decodedSymbology memberi nama symbology hasil dekode; ITF 14 digit adalah ITF-14 (GTIN karton)
+func decodedSymbology(result *gozxing.Result) string {
+	symbology, ok := zxingSymbology[result.GetBarcodeFormat()]
+	if !ok {
+		return strings.ToLower(result.GetBarcodeFormat().String())
+	}
+	if symbology == "itf" && len(result.GetText()) == 14 {
+		return SymbologyITF14
+	}
+	return symbology
+}
+
+// shrinkImage mengecilkan gambar yang sisi terpanjangnya melebihi maxScanImageSide
+func shrinkImage(img image.Image) image.Image {
+	bounds := img.Bounds()
+	longest := max(bounds.Dx(), bounds.Dy())
+	if longest <= maxScanImageSide {
+		return img
+	}
+
+	scale := float64(maxScanImageSide) / float64(longest)
+	dst := image.NewGray(image.Rect(0, 0, int(float64(bounds.Dx())*scale), int(float64(bounds.Dy())*scale)))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
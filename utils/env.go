@@ -0,0 +1,11 @@
+package utils
+
+import "os"
+
+// GetEnv membaca environment variable dengan nilai default jika kosong
+func GetEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
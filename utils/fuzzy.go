@@ -0,0 +1,99 @@
+package utils
+
+import "unicode"
+
+// Levenshtein menghitung jarak edit (sisip, hapus, ganti) antara dua string per rune
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Word adalah potongan huruf/angka di dalam teks beserta posisi rune awalnya
+type Word struct {
+	Text  string
+	Start int
+}
+
+// SplitWords memecah teks menjadi kata berhuruf kecil; tanda baca seperti - dan / menjadi pemisah
+func SplitWords(text string) []Word {
+	words := []Word{}
+	runes := []rune(text)
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			words = append(words, Word{Text: lowerRunes(runes[start:i]), Start: start})
+			start = -1
+		}
+	}
+	return words
+}
+
+// MaxTypos menentukan jumlah salah ketik yang ditoleransi berdasarkan panjang kata
+func MaxTypos(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// FuzzyPrefix returns the smallest edit distance between term and the beginning of
+// word, and whether it is within MaxTypos(term). The prefix may be one rune shorter
+// or longer than term to tolerate a dropped or doubled character from a scanner.
+func FuzzyPrefix(term, word string) (int, bool) {
+	limit := MaxTypos(term)
+	if limit == 0 {
+		return 0, false
+	}
+
+	best := limit + 1
+	tr, wr := []rune(term), []rune(word)
+	for _, n := range []int{len(tr), len(tr) - 1, len(tr) + 1} {
+		if n <= 0 || n > len(wr) {
+			continue
+		}
+		best = min(best, Levenshtein(term, string(wr[:n])))
+	}
+	return best, best <= limit
+}
+
+func lowerRunes(runes []rune) string {
+	out := make([]rune, len(runes))
+	for i, r := range runes {
+		out[i] = unicode.ToLower(r)
+	}
+	return string(out)
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// GTINCheckDigit menghitung check digit GS1 (mod 10, bobot 3-1 dari kanan) untuk digit tanpa check digit
+func GTINCheckDigit(digits string) (byte, error) {
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, errors.New("GTIN must contain digits only")
+	}
+
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// ValidGTIN reports whether code is a GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) or GTIN-14 with a correct check digit
+func ValidGTIN(code string) bool {
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+	check, err := GTINCheckDigit(code[:len(code)-1])
+	return err == nil && check == code[len(code)-1]
+}
+
+// CompleteGTIN menambahkan check digit jika code kurang satu digit dari panjang yang diminta,
+// atau memvalidasi check digit jika panjangnya sudah lengkap
+func CompleteGTIN(code string, length int) (string, error) {
+	switch len(code) {
+	case length - 1:
+		check, err := GTINCheckDigit(code)
+		if err != nil {
+			return "", err
+		}
+		return code + string(check), nil
+	case length:
+		if !ValidGTIN(code) {
+			return "", errors.New("invalid GTIN check digit")
+		}
+		return code, nil
+	default:
+		return "", errors.New("GTIN has the wrong number of digits")
+	}
+}
+
+// Symbology GS1 tambahan yang hanya dipakai untuk barcode alternatif (barcode pabrik dan karton)
+const (
+	SymbologyEAN8  = "ean8"
+	SymbologyITF14 = "itf14"
+)
+
+// gtinLengths adalah panjang GTIN yang dibawa oleh setiap symbology GS1
+var gtinLengths = map[string]int{SymbologyEAN8: 8, SymbologyUPCA: 12, SymbologyEAN13: 13, SymbologyITF14: 14}
+
+// GTINLength returns the GTIN length carried by a GS1 symbology, or 0 for other symbologies
+func GTINLength(symbology string) int {
+	return gtinLengths[symbology]
+}
+
+// GTINSymbology menebak symbology GS1 dari panjang GTIN
+func GTINSymbology(code string) string {
+	for symbology, length := range gtinLengths {
+		if len(code) == length {
+			return symbology
+		}
+	}
+	return ""
+}
+
+// GTIN14 menyeragamkan GTIN-8/12/13 menjadi GTIN-14 dengan awalan nol, sehingga UPC-A yang
+// dibaca sebagai EAN-13 tetap dianggap kode yang sama
+func GTIN14(code string) string {
+	if len(code) >= 14 {
+		return code
+	}
+	return strings.Repeat("0", 14-len(code)) + code
+}
@@ -1,27 +1,88 @@
 package utils
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var secretKey = []byte("secret") // Gantilah dengan secret key yang lebih aman
+// AccessTokenTTL membaca masa berlaku access token dari ACCESS_TOKEN_TTL (default 15 menit)
+func AccessTokenTTL() time.Duration {
+	return durationEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// RefreshTokenTTL membaca masa berlaku refresh token dari REFRESH_TOKEN_TTL (default 30 hari)
+func RefreshTokenTTL() time.Duration {
+	return durationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(GetEnv(key, "")); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// GenerateToken membuat access token JWT untuk user. Setiap token punya jti unik
+// agar bisa dicabut sebelum kedaluwarsa, dan header kid agar verifikasi tetap
+// berjalan selama rotasi kunci.
+func GenerateToken(userID uint, role string) (string, error) {
+	ring, err := activeKeyring()
+	if err != nil {
+		return "", err
+	}
+
+	jti, err := RandomToken(16)
+	if err != nil {
+		return "", err
+	}
 
-// GenerateToken membuat token JWT untuk user
-func GenerateToken(userID uint) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Expired dalam 24 jam
+		"role":    role,
+		"jti":     jti,
+		"exp":     time.Now().Add(AccessTokenTTL()).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+	if issuer := GetEnv("JWT_ISSUER", ""); issuer != "" {
+		claims["iss"] = issuer
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	token := jwt.NewWithClaims(ring.active.Method, claims)
+	token.Header["kid"] = ring.active.ID
+	return token.SignedString(ring.active.Private)
 }
 
-// ValidateToken memvalidasi token JWT
+// ValidateToken memvalidasi token JWT. Kunci dipilih berdasarkan kid dan algoritma
+// token harus sama persis dengan algoritma kunci tersebut.
 func ValidateToken(tokenString string) (*jwt.Token, error) {
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
-	})
+	ring, err := activeKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		key := ring.active
+		if kid, _ := token.Header["kid"].(string); kid != "" {
+			if key = ring.byID[kid]; key == nil {
+				return nil, errors.New("unknown key id")
+			}
+		}
+
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key.Public, nil
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(ring.algorithms()),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer := GetEnv("JWT_ISSUER", ""); issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+
+	return jwt.Parse(tokenString, keyFunc, options...)
 }
@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey adalah satu kunci JWT yang dikenali lewat kid
+type signingKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private nil berarti kunci hanya untuk verifikasi (kunci lama saat rotasi)
+	Private interface{}
+	Public  interface{}
+}
+
+type keyring struct {
+	active *signingKey
+	byID   map[string]*signingKey
+}
+
+var (
+	keys     *keyring
+	keysErr  error
+	keysOnce sync.Once
+)
+
+// JWK is a single public key in a JSON Web Key Set
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// LoadSigningKeys memuat kunci JWT dari konfigurasi. Dipanggil saat startup agar
+// konfigurasi yang salah langsung ketahuan.
+//
+//	JWT_ALGORITHM        HS256 (default), RS256 atau EdDSA
+//	JWT_SECRET           secret HS256 aktif
+//	JWT_KEY_ID           kid untuk JWT_SECRET (default "default")
+//	JWT_PREVIOUS_SECRETS secret lama yang masih diterima, format "kid=secret,kid=secret"
+//	JWT_KEYS_DIR         folder berisi <kid>.pem untuk RS256/EdDSA; file public key saja berarti verifikasi saja
+//	JWT_ACTIVE_KID       kid yang dipakai untuk menandatangani token baru
+func LoadSigningKeys() error {
+	keysOnce.Do(func() {
+		keys, keysErr = loadKeyring()
+	})
+	return keysErr
+}
+
+func loadKeyring() (*keyring, error) {
+	ring := &keyring{byID: map[string]*signingKey{}}
+
+	algorithm := GetEnv("JWT_ALGORITHM", "HS256")
+	switch algorithm {
+	case "HS256":
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET is not set")
+		}
+		ring.active = &signingKey{
+			ID:      GetEnv("JWT_KEY_ID", "default"),
+			Method:  jwt.SigningMethodHS256,
+			Private: []byte(secret),
+			Public:  []byte(secret),
+		}
+		ring.byID[ring.active.ID] = ring.active
+
+		for _, entry := range strings.Split(os.Getenv("JWT_PREVIOUS_SECRETS"), ",") {
+			kid, previous, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || kid == "" || previous == "" {
+				continue
+			}
+			ring.byID[kid] = &signingKey{ID: kid, Method: jwt.SigningMethodHS256, Public: []byte(previous)}
+		}
+
+	case "RS256", "EdDSA":
+		dir := os.Getenv("JWT_KEYS_DIR")
+		if dir == "" {
+			return nil, fmt.Errorf("JWT_KEYS_DIR is required for %s", algorithm)
+		}
+		files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			key, err := loadPEMKey(file)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			ring.byID[key.ID] = key
+		}
+
+		kid := os.Getenv("JWT_ACTIVE_KID")
+		active, ok := ring.byID[kid]
+		if !ok {
+			return nil, fmt.Errorf("JWT_ACTIVE_KID %q not found in %s", kid, dir)
+		}
+		if active.Private == nil {
+			return nil, fmt.Errorf("active key %q has no private key", kid)
+		}
+		if active.Method.Alg() != algorithm {
+			return nil, fmt.Errorf("active key %q is %s, expected %s", kid, active.Method.Alg(), algorithm)
+		}
+		ring.active = active
+
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+
+	return ring, nil
+}
+
+// loadPEMKey membaca private key (PKCS#8/PKCS#1) atau public key (PKIX) RSA/Ed25519.
+// Nama file tanpa ekstensi menjadi kid.
+func loadPEMKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key := &signingKey{ID: strings.TrimSuffix(filepath.Base(path), ".pem")}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	return key, nil
+}
+
+// algorithms mengembalikan algoritma yang dipakai oleh kunci-kunci yang dimuat
+func (r *keyring) algorithms() []string {
+	seen := map[string]bool{}
+	algs := []string{}
+	for _, key := range r.byID {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// activeKeyring memastikan kunci sudah dimuat
+func activeKeyring() (*keyring, error) {
+	if err := LoadSigningKeys(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// JWKS mengembalikan public key RS256/EdDSA yang aktif maupun lama agar layanan lain
+// bisa memverifikasi token. Kunci HS256 tidak pernah dipublikasikan.
+func JWKS() ([]JWK, error) {
+	ring, err := activeKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	set := []JWK{}
+	for _, key := range ring.byID {
+		jwk := JWK{Kid: key.ID, Alg: key.Method.Alg(), Use: "sig"}
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set = append(set, jwk)
+	}
+
+	sort.Slice(set, func(i, j int) bool { return set[i].Kid < set[j].Kid })
+	return set, nil
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// LabelTemplate adalah ukuran satu jenis kertas label dalam milimeter. Lembar Avery memiliki
+// banyak label per halaman; label thermal memakai satu label per halaman.
+type LabelTemplate struct {
+	Name        string  `json:"name" example:"avery-l7160"`
+	Description string  `json:"description" example:"A4, 21 label 63.5 x 38.1 mm"`
+	PageWidth   float64 `json:"page_width" example:"210"`
+	PageHeight  float64 `json:"page_height" example:"297"`
+	LabelWidth  float64 `json:"label_width" example:"63.5"`
+	LabelHeight float64 `json:"label_height" example:"38.1"`
+	Columns     int     `json:"columns" example:"3"`
+	Rows        int     `json:"rows" example:"7"`
+	MarginLeft  float64 `json:"margin_left" example:"7.2"`
+	MarginTop   float64 `json:"margin_top" example:"15.15"`
+	PitchX      float64 `json:"pitch_x" example:"66"`   // jarak antar awal kolom
+	PitchY      float64 `json:"pitch_y" example:"38.1"` // jarak antar awal baris
+}
+
+// PerPage returns the number of labels on one page
+func (t LabelTemplate) PerPage() int {
+	return t.Columns * t.Rows
+}
+
+// thermalTemplate membuat template untuk printer thermal yang mencetak satu label per halaman
+func thermalTemplate(name, description string, width, height float64) LabelTemplate {
+	return LabelTemplate{
+		Name: name, Description: description,
+		PageWidth: width, PageHeight: height,
+		LabelWidth: width, LabelHeight: height,
+		Columns: 1, Rows: 1,
+		PitchX: width, PitchY: height,
+	}
+}
+
+// DefaultLabelTemplate dipakai jika request tidak menyebut template
+const DefaultLabelTemplate = "avery-l7160"
+
+// LabelTemplates berisi template yang bisa dipilih, ukuran sesuai spesifikasi pabrik
+var LabelTemplates = map[string]LabelTemplate{
+	"avery-5160": {
+		Name: "avery-5160", Description: "Letter, 30 labels 66.7 x 25.4 mm (1 x 2-5/8 in)",
+		PageWidth: 215.9, PageHeight: 279.4, LabelWidth: 66.675, LabelHeight: 25.4,
+		Columns: 3, Rows: 10, MarginLeft: 4.7625, MarginTop: 12.7, PitchX: 69.85, PitchY: 25.4,
+	},
+	"avery-5163": {
+		Name: "avery-5163", Description: "Letter, 10 labels 101.6 x 50.8 mm (2 x 4 in)",
+		PageWidth: 215.9, PageHeight: 279.4, LabelWidth: 101.6, LabelHeight: 50.8,
+		Columns: 2, Rows: 5, MarginLeft: 3.96875, MarginTop: 12.7, PitchX: 107.95, PitchY: 50.8,
+	},
+	"avery-l7160": {
+		Name: "avery-l7160", Description: "A4, 21 labels 63.5 x 38.1 mm",
+		PageWidth: 210, PageHeight: 297, LabelWidth: 63.5, LabelHeight: 38.1,
+		Columns: 3, Rows: 7, MarginLeft: 7.2, MarginTop: 15.15, PitchX: 66, PitchY: 38.1,
+	},
+	"avery-l7651": {
+		Name: "avery-l7651", Description: "A4, 65 labels 38.1 x 21.2 mm",
+		PageWidth: 210, PageHeight: 297, LabelWidth: 38.1, LabelHeight: 21.2,
+		Columns: 5, Rows: 13, MarginLeft: 4.75, MarginTop: 10.7, PitchX: 40.6, PitchY: 21.2,
+	},
+	"thermal-2x1":   thermalTemplate("thermal-2x1", "Thermal roll, 50.8 x 25.4 mm (2 x 1 in)", 50.8, 25.4),
+	"thermal-4x2":   thermalTemplate("thermal-4x2", "Thermal roll, 101.6 x 50.8 mm (4 x 2 in)", 101.6, 50.8),
+	"thermal-4x6":   thermalTemplate("thermal-4x6", "Thermal roll, 101.6 x 152.4 mm (4 x 6 in)", 101.6, 152.4),
+	"thermal-58x40": thermalTemplate("thermal-58x40", "Thermal roll, 58 x 40 mm", 58, 40),
+}
+
+// SortedLabelTemplates returns all templates ordered by name
+func SortedLabelTemplates() []LabelTemplate {
+	templates := make([]LabelTemplate, 0, len(LabelTemplates))
+	for _, t := range LabelTemplates {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// LabelContent adalah isi satu label
+type LabelContent struct {
+	Name     string
+	SKU      string
+	Location string
+}
+
+// LabelOptions mengatur cetakan label
+type LabelOptions struct {
+	Symbology string
+	StartAt   int  // posisi label pertama (mulai dari 1) untuk lembar yang sudah terpakai sebagian
+	Border    bool // gambar garis tepi label untuk mengecek posisi cetak
+}
+
+// Ukuran modul barcode maksimum agar barcode pada label besar tidak melebar tanpa batas (mm)
+const (
+	maxLinearModuleMM = 0.5
+	max2DModuleMM     = 1.5
+)
+
+// WriteLabelsPDF menulis label sebagai PDF. Barcode digambar sebagai vektor sehingga tetap tajam
+// pada resolusi printer berapa pun. Setiap label berisi nama produk, barcode, SKU dan lokasi.
+func WriteLabelsPDF(w io.Writer, template LabelTemplate, labels []LabelContent, opts LabelOptions) error {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		UnitStr: "mm",
+		Size:    fpdf.SizeType{Wd: template.PageWidth, Ht: template.PageHeight},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetCreator("warehouse-backend", true)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	position := max(opts.StartAt, 1) - 1
+	for i, label := range labels {
+		slot := position + i
+		if i == 0 || slot%template.PerPage() == 0 {
+			pdf.AddPage()
+		}
+		slot %= template.PerPage()
+		x := template.MarginLeft + float64(slot%template.Columns)*template.PitchX
+		y := template.MarginTop + float64(slot/template.Columns)*template.PitchY
+
+		if err := drawLabel(pdf, translate, x, y, template.LabelWidth, template.LabelHeight, label, opts); err != nil {
+			return fmt.Errorf("label %s: %w", label.SKU, err)
+		}
+	}
+
+	return pdf.Output(w)
+}
+
+// drawLabel menggambar satu label: nama (tebal) di atas, barcode di tengah, SKU dan lokasi di bawah
+func drawLabel(pdf *fpdf.Fpdf, translate func(string) string, x, y, w, h float64, label LabelContent, opts LabelOptions) error {
+	if opts.Border {
+		pdf.SetDrawColor(180, 180, 180)
+		pdf.SetLineWidth(0.1)
+		pdf.Rect(x, y, w, h, "D")
+	}
+
+	pad := min(2.5, h*0.08)
+	lineHeight := min(max(h*0.13, 2.6), 6)
+	fontSize := lineHeight / 0.3528 * 0.8 // mm ke point, sisakan jarak antar baris
+	inner := w - 2*pad
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", fontSize)
+	pdf.SetXY(x+pad, y+pad)
+	pdf.CellFormat(inner, lineHeight, fitText(pdf, translate(label.Name), inner), "", 0, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", fontSize)
+	bottom := y + h - pad - lineHeight
+	skuWidth := pdf.GetStringWidth(label.SKU)
+	pdf.SetXY(x+pad, bottom)
+	pdf.CellFormat(inner, lineHeight, fitText(pdf, translate(label.SKU), inner), "", 0, "L", false, 0, "")
+	if label.Location != "" && skuWidth+4 < inner {
+		pdf.SetXY(x+pad+skuWidth+4, bottom)
+		space := inner - skuWidth - 4
+		pdf.CellFormat(space, lineHeight, fitText(pdf, translate(label.Location), space), "", 0, "R", false, 0, "")
+	}
+
+	top := y + pad + lineHeight + pad/2
+	return drawBarcodePDF(pdf, label.SKU, opts.Symbology, x+pad, top, inner, bottom-pad/2-top)
+}
+
+// drawBarcodePDF menggambar barcode sebagai persegi panjang vektor di dalam kotak (x, y, w, h),
+// rata tengah. Quiet zone tetap dihitung agar barcode terbaca meski label bersebelahan.
+func drawBarcodePDF(pdf *fpdf.Fpdf, content, symbology string, x, y, w, h float64) error {
+	opts := DefaultBarcodeOptions(symbology)
+	opts.ModuleWidth = 1
+	opts.ShowText = false
+	l, err := layoutBarcode(content, opts)
+	if err != nil {
+		return err
+	}
+
+	module := min(w/float64(l.cols+2*opts.QuietZone), maxLinearModuleMM)
+	barHeight := h
+	if l.twoD {
+		module = min(w/float64(l.cols+2*opts.QuietZone), h/float64(l.rows+2*opts.QuietZone), max2DModuleMM)
+		barHeight = module
+	}
+	if barHeight <= 0 || module <= 0 {
+		return errors.New("label is too small for the barcode")
+	}
+
+	left := x + (w-float64(l.cols)*module)/2
+	top := y
+	if l.twoD {
+		top = y + (h-float64(l.rows)*module)/2
+	}
+
+	pdf.SetFillColor(0, 0, 0)
+	rows := l.rows
+	if !l.twoD {
+		rows = 1
+	}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < l.cols; {
+			if !l.dark(col, row) {
+				col++
+				continue
+			}
+			run := 1
+			for col+run < l.cols && l.dark(col+run, row) {
+				run++
+			}
+			pdf.Rect(left+float64(col)*module, top+float64(row)*module, float64(run)*module, barHeight, "F")
+			col += run
+		}
+	}
+	return nil
+}
+
+// fitText memotong text dengan "..." agar lebarnya tidak melebihi width pada font aktif
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	// Teks sudah diterjemahkan ke cp1252 sehingga satu byte adalah satu karakter
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// aimIdentifier adalah awalan symbology identifier AIM (misalnya ]C1, ]E0, ]Q1) yang
+// ditambahkan sebagian scanner di depan hasil scan
+var aimIdentifier = regexp.MustCompile(`^\][A-Za-z][0-9A-Za-z]`)
+
+// NormalizeScan membersihkan hasil scan: karakter kontrol dan spasi di ujung (CR/LF/Tab dari
+// scanner keyboard-wedge) serta awalan AIM symbology identifier
+func NormalizeScan(code string) string {
+	code = strings.TrimFunc(code, func(r rune) bool { return r <= ' ' || r == 0x7f })
+	code = aimIdentifier.ReplaceAllString(code, "")
+	return strings.TrimSpace(code)
+}
+
+// GTINVariants mengembalikan semua bentuk yang setara dari sebuah GTIN: UPC-A 12 digit,
+// EAN-13 dengan awalan 0 dan GTIN-14 dengan awalan nol menunjuk barang yang sama. Hasil
+// scan GS1 dengan Application Identifier 01 ("01" + 14 digit atau "(01)" + 14 digit) juga
+// dikenali. Mengembalikan nil jika code bukan GTIN yang valid.
+func GTINVariants(code string) []string {
+	switch {
+	case strings.HasPrefix(code, "(01)"):
+		code = code[4:]
+	case len(code) == 16 && strings.HasPrefix(code, "01"):
+		code = code[2:]
+	}
+	if !ValidGTIN(code) {
+		return nil
+	}
+
+	core := strings.TrimLeft(code, "0")
+	variants := []string{code}
+	for _, length := range []int{8, 12, 13, 14} {
+		if len(core) > length {
+			continue
+		}
+		variant := strings.Repeat("0", length-len(core)) + core
+		if variant != code {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
@@ -1,12 +1,190 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// DefaultSKUPattern dipakai jika SKU_PATTERN tidak diisi
+const DefaultSKUPattern = "SKU-{YYYY}-{SEQ:6}"
+
+const skuAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+var (
+	skuPlaceholder = regexp.MustCompile(`\{(CAT|WH|YYYY|YY|MM|DD|SEQ(?::([1-9]|1[0-9]))?|CHK)\}`)
+	validSKU       = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,99}$`)
+)
+
+// SKUFields adalah nilai yang dapat dipakai di dalam pola SKU
+type SKUFields struct {
+	Category  string
+	Warehouse string
+	Time      time.Time
+}
+
 // GenerateSKU membuat SKU berdasarkan timestamp
 func GenerateSKU() string {
 	timestamp := time.Now().UnixNano() / int64(time.Millisecond) // Timestamp dalam milidetik
 	return fmt.Sprintf("SKU-%d", timestamp)
 }
+
+// SKUPattern membaca pola SKU dari SKU_PATTERN, misalnya {CAT}-{YYYY}-{SEQ:6}{CHK}
+func SKUPattern() string {
+	return GetEnv("SKU_PATTERN", DefaultSKUPattern)
+}
+
+// ValidateSKUPattern memastikan pola hanya memakai placeholder yang dikenal dan memiliki tepat satu {SEQ}
+func ValidateSKUPattern(pattern string) error {
+	seq, chk := 0, 0
+	for _, match := range skuPlaceholder.FindAllStringSubmatch(pattern, -1) {
+		switch {
+		case strings.HasPrefix(match[1], "SEQ"):
+			seq++
+		case match[1] == "CHK":
+			chk++
+		}
+	}
+	if seq != 1 {
+		return errors.New("SKU pattern must contain exactly one {SEQ} or {SEQ:n}")
+	}
+	if chk > 1 {
+		return errors.New("SKU pattern may contain at most one {CHK}")
+	}
+	if rest := skuPlaceholder.ReplaceAllString(pattern, ""); strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("SKU pattern %q contains an unknown placeholder", pattern)
+	}
+	sample := RenderSKU(pattern, SKUFields{Category: "GEN", Warehouse: "WH", Time: time.Now()}, 1)
+	if err := ValidateSKU(sample); err != nil {
+		return fmt.Errorf("SKU pattern %q produces invalid SKUs: %w", pattern, err)
+	}
+	return nil
+}
+
+// ValidateSKUSymbology memastikan SKU dari pola bisa di-encode dengan symbology barcode. EAN-13
+// dan UPC-A hanya menerima angka, jadi {CAT}, {WH}, {CHK} (mod 36) dan huruf di pola ditolak.
+func ValidateSKUSymbology(pattern, symbology string) error {
+	if symbology != SymbologyEAN13 && symbology != SymbologyUPCA {
+		return nil
+	}
+
+	for _, match := range skuPlaceholder.FindAllStringSubmatch(pattern, -1) {
+		if match[1] == "CAT" || match[1] == "WH" || match[1] == "CHK" {
+			return fmt.Errorf("SKU pattern %q can produce letters via {%s}, but %s only encodes digits", pattern, match[1], symbology)
+		}
+	}
+	sample := RenderSKU(pattern, SKUFields{Time: time.Now()}, 1)
+	if strings.Trim(sample, "0123456789") != "" {
+		return fmt.Errorf("SKU pattern %q produces %q with non-digit characters, but %s only encodes digits", pattern, sample, symbology)
+	}
+	if _, _, err := EncodeBarcode(sample, symbology); err != nil {
+		return fmt.Errorf("SKU pattern %q produces %q which cannot be encoded as %s: %w", pattern, sample, symbology, err)
+	}
+	return nil
+}
+
+// SKUSequenceKey merender pola tanpa {SEQ} dan {CHK}. Setiap key memiliki nomor urut sendiri,
+// sehingga {CAT}-{YYYY}-{SEQ:6} dimulai lagi dari 1 untuk setiap kategori dan tahun.
+func SKUSequenceKey(pattern string, fields SKUFields) string {
+	return renderSKU(pattern, fields, func(token string) string {
+		if strings.HasPrefix(token, "SEQ") {
+			return "#"
+		}
+		return ""
+	})
+}
+
+// RenderSKU mengisi pola dengan nilai fields dan nomor urut seq. {CHK} diisi check character
+// Luhn mod 36 yang dihitung dari semua huruf dan angka lain di SKU.
+func RenderSKU(pattern string, fields SKUFields, seq uint64) string {
+	const marker = "\x00"
+	sku := renderSKU(pattern, fields, func(token string) string {
+		if token == "CHK" {
+			return marker
+		}
+		width := 1
+		if _, digits, ok := strings.Cut(token, ":"); ok {
+			width, _ = strconv.Atoi(digits)
+		}
+		return fmt.Sprintf("%0*d", width, seq)
+	})
+
+	if strings.Contains(sku, marker) {
+		sku = strings.Replace(sku, marker, SKUCheckChar(strings.Replace(sku, marker, "", 1)), 1)
+	}
+	return sku
+}
+
+// renderSKU mengganti placeholder tanggal, kategori dan gudang; token lain diserahkan ke fn
+func renderSKU(pattern string, fields SKUFields, fn func(token string) string) string {
+	t := fields.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	return skuPlaceholder.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		token := strings.Trim(placeholder, "{}")
+		switch token {
+		case "CAT":
+			return skuToken(fields.Category, "GEN")
+		case "WH":
+			return skuToken(fields.Warehouse, "WH")
+		case "YYYY":
+			return t.Format("2006")
+		case "YY":
+			return t.Format("06")
+		case "MM":
+			return t.Format("01")
+		case "DD":
+			return t.Format("02")
+		default:
+			return fn(token)
+		}
+	})
+}
+
+// skuToken membersihkan nilai bebas menjadi huruf besar/angka maksimal 10 karakter
+func skuToken(value, fallback string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if strings.ContainsRune(skuAlphabet, r) {
+			b.WriteRune(r)
+		}
+		if b.Len() == 10 {
+			break
+		}
+	}
+	if b.Len() == 0 {
+		return fallback
+	}
+	return b.String()
+}
+
+// ValidateSKU memeriksa SKU yang diisi sendiri oleh user
+func ValidateSKU(sku string) error {
+	if !validSKU.MatchString(sku) {
+		// "/" tidak diizinkan karena SKU dipakai sebagai nama file barcode dan segmen URL
+		return errors.New("SKU must be 1-100 characters of letters, digits, '-', '_' or '.' and start with a letter or digit")
+	}
+	return nil
+}
+
+// SKUCheckChar menghitung check character Luhn mod 36 dari huruf dan angka di s
+func SKUCheckChar(s string) string {
+	const n = 36
+	factor, sum := 2, 0
+	runes := []rune(strings.ToUpper(s))
+	for i := len(runes) - 1; i >= 0; i-- {
+		code := strings.IndexRune(skuAlphabet, runes[i])
+		if code < 0 {
+			continue
+		}
+		addend := factor * code
+		factor = 3 - factor
+		sum += addend/n + addend%n
+	}
+	return string(skuAlphabet[(n-sum%n)%n])
+}
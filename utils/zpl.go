@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultZPLTemplate dipakai jika request ZPL tidak menyebut template
+const DefaultZPLTemplate = "thermal-2x1"
+
+// DefaultZPLPort adalah port raw printing (JetDirect) printer Zebra
+const DefaultZPLPort = "9100"
+
+// Batas waktu koneksi dan pengiriman ke printer
+const (
+	printerDialTimeout  = 5 * time.Second
+	printerWriteTimeout = 10 * time.Second
+)
+
+// zplDotsPerMM adalah resolusi printer Zebra yang didukung (dpi ke dot per mm)
+var zplDotsPerMM = map[int]int{152: 6, 203: 8, 300: 12, 600: 24}
+
+// ValidZPLDPI checks whether dpi is a Zebra print head resolution
+func ValidZPLDPI(dpi int) bool {
+	_, ok := zplDotsPerMM[dpi]
+	return ok
+}
+
+// ZPLDPI membaca resolusi printer dari ZPL_DPI (default 203)
+func ZPLDPI() int {
+	dpi, err := strconv.Atoi(GetEnv("ZPL_DPI", "203"))
+	if err != nil || !ValidZPLDPI(dpi) {
+		return 203
+	}
+	return dpi
+}
+
+// ZPLPrinter membaca alamat printer dari ZPL_PRINTER; kosong berarti pencetakan langsung dimatikan
+func ZPLPrinter() string {
+	return PrinterAddress(GetEnv("ZPL_PRINTER", ""))
+}
+
+// PrinterAddress menambahkan port 9100 jika alamat printer tidak menyebut port
+func PrinterAddress(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return ""
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return net.JoinHostPort(strings.Trim(addr, "[]"), DefaultZPLPort)
+	}
+	return addr
+}
+
+// ZPLOptions mengatur label ZPL
+type ZPLOptions struct {
+	Symbology string
+	DPI       int
+	Copies    int
+}
+
+// zplEscape menyiapkan teks untuk ^FH: karakter perintah ZPL ditulis sebagai hex
+func zplEscape(text string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(text)
+}
+
+// zplTruncate memotong teks agar muat dalam width dot untuk font 0 setinggi height dot.
+// Lebar karakter font 0 tidak tetap sehingga dipakai perkiraan 0,6 x tinggi.
+func zplTruncate(text string, width, height int) string {
+	limit := int(float64(width) / (float64(height) * 0.6))
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	if limit <= 3 {
+		return string(runes[:max(limit, 0)])
+	}
+	return string(runes[:limit-3]) + "..."
+}
+
+// ZPLLabel membuat satu label ZPL II dengan perintah barcode bawaan printer (^BC untuk Code 128)
+// sehingga bar dicetak langsung oleh printer tanpa driver. Isi label sama dengan PDF:
+// nama di atas, barcode di tengah, SKU dan lokasi di bawah.
+func ZPLLabel(template LabelTemplate, label LabelContent, opts ZPLOptions) (string, error) {
+	dpmm, ok := zplDotsPerMM[opts.DPI]
+	if !ok {
+		return "", fmt.Errorf("unsupported printer resolution %d dpi", opts.DPI)
+	}
+	dots := func(mm float64) int { return int(math.Round(mm * float64(dpmm))) }
+
+	width, height := dots(template.LabelWidth), dots(template.LabelHeight)
+	pad := dots(min(2.5, template.LabelHeight*0.08))
+	fontHeight := dots(min(max(template.LabelHeight*0.13, 2.6), 6) * 0.8)
+	inner := width - 2*pad
+
+	var b strings.Builder
+	b.WriteString("^XA\n^CI28\n")
+	fmt.Fprintf(&b, "^PW%d\n^LL%d\n^LH0,0\n", width, height)
+
+	// Nama produk
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", pad, pad, fontHeight, fontHeight,
+		zplEscape(zplTruncate(label.Name, inner, fontHeight)))
+
+	// SKU di kiri bawah, lokasi di kanan bawah
+	bottom := height - pad - fontHeight
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", pad, bottom, fontHeight, fontHeight, zplEscape(label.SKU))
+	if label.Location != "" {
+		skuWidth := int(float64(len([]rune(label.SKU))+2) * float64(fontHeight) * 0.6)
+		if space := inner - skuWidth; space > fontHeight {
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,R,0^FH^FD%s^FS\n", pad+skuWidth, bottom, fontHeight, fontHeight,
+				space, zplEscape(zplTruncate(label.Location, space, fontHeight)))
+		}
+	}
+
+	top := pad + fontHeight + pad/2
+	command, err := zplBarcode(label.SKU, opts.Symbology, dpmm, width, top, bottom-pad/2-top)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(command)
+
+	if opts.Copies > 1 {
+		fmt.Fprintf(&b, "^PQ%d\n", opts.Copies)
+	}
+	b.WriteString("^XZ\n")
+	return b.String(), nil
+}
+
+// zplBarcode menulis perintah barcode untuk area selebar label mulai dari top setinggi height dot.
+// Lebar modul (^BY) dipilih sebesar mungkin agar barcode beserta quiet zone muat, lalu dibuat rata tengah.
+func zplBarcode(content, symbology string, dpmm, width, top, height int) (string, error) {
+	opts := DefaultBarcodeOptions(symbology)
+	opts.ModuleWidth = 1
+	opts.ShowText = false
+	l, err := layoutBarcode(content, opts)
+	if err != nil {
+		return "", err
+	}
+
+	// Batas ukuran modul sama dengan label PDF, ^BY sendiri maksimal 10 dot
+	module := min(width/(l.cols+2*opts.QuietZone), max(1, int(maxLinearModuleMM*float64(dpmm))), 10)
+	if l.twoD {
+		module = min(width/(l.cols+2*opts.QuietZone), height/(l.rows+2*opts.QuietZone), int(max2DModuleMM*float64(dpmm)), 10)
+	}
+	if module < 1 || height < 1 {
+		return "", errors.New("label is too small for the barcode")
+	}
+
+	x := (width - l.cols*module) / 2
+	if l.twoD {
+		top += (height - l.rows*module) / 2
+	}
+
+	_, text, _ := EncodeBarcode(content, symbology)
+	switch symbology {
+	case SymbologyCode128:
+		// Mode A: printer memilih subset Code 128 secara otomatis
+		return fmt.Sprintf("^BY%d\n^FO%d,%d^BCN,%d,N,N,N,A^FH^FD%s^FS\n", module, x, top, height, zplEscape(content)), nil
+	case SymbologyCode39:
+		return fmt.Sprintf("^BY%d,3\n^FO%d,%d^B3N,N,%d,N,N^FH^FD%s^FS\n", module, x, top, height, zplEscape(content)), nil
+	case SymbologyEAN13:
+		// Printer menghitung sendiri check digit dari 12 digit pertama
+		return fmt.Sprintf("^BY%d\n^FO%d,%d^BEN,%d,N,N^FD%s^FS\n", module, x, top, height, text[:12]), nil
+	case SymbologyUPCA:
+		return fmt.Sprintf("^BY%d\n^FO%d,%d^BUN,%d,N,N,N^FD%s^FS\n", module, x, top, height, text[:11]), nil
+	case SymbologyQR:
+		return fmt.Sprintf("^FO%d,%d^BQN,2,%d^FH^FDMA,%s^FS\n", x, top, module, zplEscape(content)), nil
+	case SymbologyDataMatrix:
+		return fmt.Sprintf("^FO%d,%d^BXN,%d,200^FH^FD%s^FS\n", x, top, module, zplEscape(content)), nil
+	}
+	return "", fmt.Errorf("unsupported symbology %q", symbology)
+}
+
+// SendToPrinter mengirim data mentah (ZPL) ke printer lewat TCP, biasanya port 9100
+func SendToPrinter(addr string, data []byte) error {
+	conn, err := net.DialTimeout("tcp", PrinterAddress(addr), printerDialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(printerWriteTimeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write(data)
+	return err
+}